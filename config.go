@@ -8,12 +8,19 @@ import (
 	"strings"
 
 	"github.com/get-glu/glu/internal/git"
+	"github.com/get-glu/glu/internal/helm"
 	"github.com/get-glu/glu/internal/oci"
+	"github.com/get-glu/glu/pkg/approvals"
+	"github.com/get-glu/glu/pkg/auth"
 	"github.com/get-glu/glu/pkg/config"
 	"github.com/get-glu/glu/pkg/containers"
 	"github.com/get-glu/glu/pkg/credentials"
+	"github.com/get-glu/glu/pkg/scm/bitbucket"
+	"github.com/get-glu/glu/pkg/scm/gitea"
 	"github.com/get-glu/glu/pkg/scm/github"
+	"github.com/get-glu/glu/pkg/scm/gitlab"
 	srcgit "github.com/get-glu/glu/pkg/src/git"
+	srchttp "github.com/get-glu/glu/pkg/src/http"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	giturls "github.com/whilp/git-urls"
@@ -26,9 +33,12 @@ type Config struct {
 	creds *credentials.CredentialSource
 
 	cache struct {
-		oci      map[string]*oci.Repository
-		repo     map[string]*git.Repository
-		proposer map[string]srcgit.Proposer
+		oci       map[string]*oci.Repository
+		helm      map[string]*helm.Repository
+		http      map[string]*srchttp.Endpoint
+		repo      map[string]*git.Repository
+		proposer  map[string]srcgit.Proposer
+		approvals approvals.Store
 	}
 }
 
@@ -39,6 +49,8 @@ func newConfigSource(conf *config.Config) *Config {
 	}
 
 	c.cache.oci = map[string]*oci.Repository{}
+	c.cache.helm = map[string]*helm.Repository{}
+	c.cache.http = map[string]*srchttp.Endpoint{}
 	c.cache.repo = map[string]*git.Repository{}
 	c.cache.proposer = map[string]srcgit.Proposer{}
 
@@ -77,6 +89,10 @@ func (c *Config) GitRepository(ctx context.Context, name string) (_ *git.Reposit
 		srcOpts = append(srcOpts, git.WithFilesystemStorage(conf.Path))
 	}
 
+	if conf.PollInterval > 0 {
+		srcOpts = append(srcOpts, git.WithInterval(conf.PollInterval))
+	}
+
 	if conf.Remote != nil {
 		slog.Debug("configuring remote", "remote", conf.Remote.Name)
 
@@ -95,6 +111,20 @@ func (c *Config) GitRepository(ctx context.Context, name string) (_ *git.Reposit
 		}
 	}
 
+	if conf.Signing != nil {
+		creds, err := c.creds.Get(conf.Signing.Credential)
+		if err != nil {
+			return nil, nil, fmt.Errorf("repository %q: %w", name, err)
+		}
+
+		signer, err := creds.CommitSigner()
+		if err != nil {
+			return nil, nil, fmt.Errorf("repository %q: %w", name, err)
+		}
+
+		srcOpts = append(srcOpts, git.WithSigner(signer))
+	}
+
 	if method == nil {
 		method, err = ssh.DefaultAuthBuilder("git")
 		if err != nil {
@@ -102,7 +132,9 @@ func (c *Config) GitRepository(ctx context.Context, name string) (_ *git.Reposit
 		}
 	}
 
-	repo, err := git.NewRepository(context.Background(), slog.Default(), append(srcOpts, git.WithAuth(method))...)
+	// the repository outlives the context it is built with, but must keep any events publisher
+	// it carries so that changes observed while polling are published
+	repo, err := git.NewRepository(context.WithoutCancel(ctx), slog.Default(), append(srcOpts, git.WithAuth(method))...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -113,14 +145,16 @@ func (c *Config) GitRepository(ctx context.Context, name string) (_ *git.Reposit
 			return nil, nil, err
 		}
 
-		parts := strings.SplitN(strings.TrimPrefix(repoURL.Path, "/"), "/", 2)
+		projectPath := strings.TrimSuffix(strings.TrimPrefix(repoURL.Path, "/"), ".git")
+
+		parts := strings.SplitN(projectPath, "/", 2)
 		if len(parts) < 2 {
 			return nil, nil, fmt.Errorf("unexpected repository URL path: %q", repoURL.Path)
 		}
 
 		var (
 			repoOwner = parts[0]
-			repoName  = strings.TrimSuffix(parts[1], ".git")
+			repoName  = parts[1]
 		)
 
 		var proposalsEnabled bool
@@ -130,19 +164,63 @@ func (c *Config) GitRepository(ctx context.Context, name string) (_ *git.Reposit
 				return nil, nil, err
 			}
 
-			client, err := creds.GitHubClient(ctx)
-			if err != nil {
-				return nil, nil, err
+			switch conf.Proposals.Type {
+			case config.ProposalsTypeGitLab:
+				apiURL := conf.Proposals.APIURL
+				if apiURL == "" {
+					apiURL = fmt.Sprintf("https://%s/api/v4", repoURL.Hostname())
+				}
+
+				client, err := creds.HTTPClient(ctx)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				proposer = gitlab.New(client, apiURL, projectPath)
+			case config.ProposalsTypeGitea:
+				apiURL := conf.Proposals.APIURL
+				if apiURL == "" {
+					apiURL = fmt.Sprintf("https://%s/api/v1", repoURL.Hostname())
+				}
+
+				client, err := creds.HTTPClient(ctx)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				proposer = gitea.New(client, apiURL, repoOwner, repoName)
+			case config.ProposalsTypeBitbucket:
+				apiURL := conf.Proposals.APIURL
+				if apiURL == "" {
+					apiURL = fmt.Sprintf("https://%s/rest/api/1.0", repoURL.Hostname())
+				}
+
+				client, err := creds.HTTPClient(ctx)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				// HTTP clone URLs are of the form /scm/<project>/<repo>.git
+				projectKey, repoSlug, _ := strings.Cut(strings.TrimPrefix(projectPath, "scm/"), "/")
+
+				proposer = bitbucket.New(client, apiURL, projectKey, repoSlug)
+			default:
+				// api_url targets GitHub Enterprise Server (defaults to api.github.com)
+				client, err := creds.GitHubClient(ctx, conf.Proposals.APIURL, conf.Proposals.UploadURL)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				proposer = github.New(
+					client,
+					repoOwner,
+					repoName,
+				)
 			}
-
-			proposer = github.New(
-				client,
-				repoOwner,
-				repoName,
-			)
 		}
 
 		slog.Debug("configured scm proposer",
+			slog.String("type", string(conf.Proposals.Type)),
 			slog.String("owner", repoOwner),
 			slog.String("name", repoName),
 			slog.Bool("proposals_enabled", proposalsEnabled),
@@ -178,7 +256,7 @@ func (c *Config) OCIRepository(name string) (_ *oci.Repository, err error) {
 		}
 	}
 
-	repo, err := oci.New(conf.Reference, cred)
+	repo, err := oci.New(*conf, cred)
 	if err != nil {
 		return nil, err
 	}
@@ -188,6 +266,161 @@ func (c *Config) OCIRepository(name string) (_ *oci.Repository, err error) {
 	return repo, nil
 }
 
+// HelmRepository constructs and configures an instance of a *helm.Repository
+// using the name to lookup the relevant configuration.
+// It caches built instances and returns the same instance for subsequent
+// calls with the same name.
+func (c *Config) HelmRepository(ctx context.Context, name string) (_ *helm.Repository, err error) {
+	// check cache for previously built repository
+	if repo, ok := c.cache.helm[name]; ok {
+		return repo, nil
+	}
+
+	conf, ok := c.conf.Sources.Helm[name]
+	if !ok {
+		return nil, fmt.Errorf("helm %q: configuration not found", name)
+	}
+
+	var cred *credentials.Credential
+	if conf.Credential != "" {
+		cred, err = c.creds.Get(conf.Credential)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	repo, err := helm.New(ctx, conf.URL, conf.Chart, conf.Version, cred)
+	if err != nil {
+		return nil, fmt.Errorf("helm %q: %w", name, err)
+	}
+
+	c.cache.helm[name] = repo
+
+	return repo, nil
+}
+
+// HTTPEndpoint constructs and configures an instance of a *srchttp.Endpoint
+// using the name to lookup the relevant configuration.
+// It caches built instances and returns the same instance for subsequent
+// calls with the same name.
+func (c *Config) HTTPEndpoint(ctx context.Context, name string) (_ *srchttp.Endpoint, err error) {
+	// check cache for previously built endpoint
+	if endpoint, ok := c.cache.http[name]; ok {
+		return endpoint, nil
+	}
+
+	conf, ok := c.conf.Sources.HTTP[name]
+	if !ok {
+		return nil, fmt.Errorf("http %q: configuration not found", name)
+	}
+
+	var opts []containers.Option[srchttp.Endpoint]
+	if conf.Credential != "" {
+		cred, err := c.creds.Get(conf.Credential)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := cred.HTTPClient(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("http %q: %w", name, err)
+		}
+
+		opts = append(opts, srchttp.WithHTTPClient(client))
+	}
+
+	for key, value := range conf.Headers {
+		opts = append(opts, srchttp.WithHeader(key, value))
+	}
+
+	endpoint := srchttp.NewEndpoint(conf.URL, opts...)
+
+	c.cache.http[name] = endpoint
+
+	return endpoint, nil
+}
+
+// ApprovalStore constructs and configures the approvals.Store used to record
+// and decide upon promotions for phases which require approval.
+// It returns a file-backed store when a path is configured and an in-memory store otherwise.
+// It caches the built store and returns the same instance for subsequent calls.
+func (c *Config) ApprovalStore() (_ approvals.Store, err error) {
+	if c.cache.approvals != nil {
+		return c.cache.approvals, nil
+	}
+
+	if path := c.conf.Approvals.Path; path != "" {
+		c.cache.approvals, err = approvals.NewFileStore(path)
+		if err != nil {
+			return nil, fmt.Errorf("approvals: %w", err)
+		}
+	} else {
+		// approvals decided by another process (e.g. the CLI) are not visible to a memory store
+		slog.Warn("approvals are stored in-memory, configure approvals.path to decide upon them from the CLI")
+		c.cache.approvals = approvals.NewMemoryStore()
+	}
+
+	return c.cache.approvals, nil
+}
+
+// Authenticators returns the API authenticators derived from the auth configuration.
+// An empty result signifies that the API should not require authentication.
+func (c *Config) Authenticators() (authenticators []auth.Authenticator) {
+	if tlsConf := c.conf.Server.TLS; tlsConf != nil && tlsConf.ClientCAFile != "" {
+		authenticators = append(authenticators, auth.ClientCertificates())
+	}
+
+	if tokens := c.conf.Auth.Tokens; len(tokens) > 0 {
+		identities := map[string]*auth.Identity{}
+		for _, token := range tokens {
+			id := &auth.Identity{Subject: token.Subject, Name: token.Subject, Attributes: map[string][]string{}}
+			for k, v := range token.Attributes {
+				id.Attributes[k] = []string{v}
+			}
+
+			identities[token.Token] = id
+		}
+
+		authenticators = append(authenticators, auth.StaticTokens(identities))
+	}
+
+	if jwt := c.conf.Auth.JWT; jwt != nil {
+		keys := auth.KeySetFromFile(jwt.JWKSFile, auth.WithRefreshInterval(jwt.RefreshInterval))
+		if jwt.JWKSURL != "" {
+			keys = auth.KeySetFromURL(jwt.JWKSURL, auth.WithRefreshInterval(jwt.RefreshInterval))
+		}
+
+		authenticators = append(authenticators, auth.JWT(keys,
+			auth.WithIssuer(jwt.Issuer),
+			auth.WithAudience(jwt.Audience),
+		))
+	}
+
+	return
+}
+
+// Authorizer returns the API authorizer derived from the configured auth rules.
+func (c *Config) Authorizer() auth.Authorizer {
+	var rules auth.Rules
+	for _, rule := range c.conf.Auth.Rules {
+		r := auth.Rule{
+			Pipelines:      rule.Pipelines,
+			PipelineLabels: rule.PipelineLabels,
+			PhaseLabels:    rule.PhaseLabels,
+			Subjects:       rule.Subjects,
+			Attributes:     rule.Attributes,
+		}
+
+		for _, action := range rule.Actions {
+			r.Actions = append(r.Actions, auth.Action(action))
+		}
+
+		rules = append(rules, r)
+	}
+
+	return rules
+}
+
 // GetCredential delegates to an underlying credential source
 // built using the same underlying credential configuration.
 func (c *Config) GetCredential(name string) (*credentials.Credential, error) {
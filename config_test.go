@@ -0,0 +1,185 @@
+package glu
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/get-glu/glu/pkg/config"
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/fs"
+	"github.com/get-glu/glu/pkg/phases"
+	srcgit "github.com/get-glu/glu/pkg/src/git"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+type versionResource struct {
+	version string
+}
+
+func (v *versionResource) Digest() (string, error) {
+	return v.version, nil
+}
+
+func (v *versionResource) ReadFrom(_ context.Context, _ core.Metadata, fs fs.Filesystem) error {
+	fi, err := fs.OpenFile("version", os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+
+	defer fi.Close()
+
+	data, err := io.ReadAll(fi)
+	if err != nil {
+		return err
+	}
+
+	v.version = string(data)
+
+	return nil
+}
+
+func (v *versionResource) WriteTo(_ context.Context, _ core.Metadata, fs fs.Filesystem) error {
+	fi, err := fs.OpenFile("version", os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	defer fi.Close()
+
+	_, err = fi.Write([]byte(v.version))
+	return err
+}
+
+func TestGitRepository_PolledChangesArePublished(t *testing.T) {
+	upstream, commit := newUpstream(t)
+	commit("v1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	conf := &config.Config{
+		Credentials: config.Credentials{
+			"upstream": {Type: config.CredentialTypeBasic, Basic: &config.BasicAuthConfig{}},
+		},
+	}
+	conf.Sources.Git = config.GitRepositories{
+		"upstream": {
+			DefaultBranch: "main",
+			PollInterval:  20 * time.Millisecond,
+			Remote:        &config.Remote{Name: "origin", URL: upstream, Credential: "upstream"},
+		},
+	}
+
+	system := NewSystem(ctx, Name("test"))
+	system.conf = newConfigSource(conf)
+	system.AddPipeline(func(ctx context.Context, config *Config) (Pipeline, error) {
+		repo, proposer, err := config.GitRepository(ctx, "upstream")
+		if err != nil {
+			return nil, err
+		}
+
+		t.Cleanup(func() { _ = repo.Close() })
+
+		pipeline := NewPipeline(Name("app"), func() *versionResource { return &versionResource{} })
+		if _, err := phases.New(Name("staging"), pipeline, srcgit.NewSource[*versionResource](repo, proposer)); err != nil {
+			return nil, err
+		}
+
+		return pipeline, nil
+	})
+
+	if system.err != nil {
+		t.Fatal(system.err)
+	}
+
+	srv := httptest.NewServer(newServer(system))
+	t.Cleanup(srv.Close)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v1/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, found %d", resp.StatusCode)
+	}
+
+	// the change is only observed by the repository when it next polls the remote
+	commit("v2")
+
+	found := make(chan string)
+	go func() {
+		defer close(found)
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if event, ok := strings.CutPrefix(scanner.Text(), "event: "); ok {
+				found <- event
+				return
+			}
+		}
+	}()
+
+	select {
+	case event := <-found:
+		if event != "phase.changed" {
+			t.Errorf("expected event %q, found %q", "phase.changed", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the polled change to be published")
+	}
+}
+
+// newUpstream initializes a repository to act as a remote and returns its path
+// along with a function which commits the provided version to its main branch.
+func newUpstream(t *testing.T) (string, func(string)) {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := gogit.PlainInitWithOptions(dir, &gogit.PlainInitOptions{
+		InitOptions: gogit.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName("main")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, func(version string) {
+		t.Helper()
+
+		if err := os.WriteFile(filepath.Join(dir, "version"), []byte(version), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tree.Add("version"); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := tree.Commit("set version "+version, &gogit.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -2,11 +2,14 @@ package glu
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"iter"
 	"log/slog"
 	"maps"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,10 +17,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/get-glu/glu/internal/certs"
+	"github.com/get-glu/glu/pkg/approvals"
 	"github.com/get-glu/glu/pkg/cli"
 	"github.com/get-glu/glu/pkg/config"
 	"github.com/get-glu/glu/pkg/containers"
 	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/events"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -70,19 +76,21 @@ type System struct {
 	triggers  []Trigger
 	err       error
 
-	server *Server
+	events *events.Bus
 }
 
 // NewSystem constructs and configures a new system with the provided metadata.
 func NewSystem(ctx context.Context, meta Metadata) *System {
+	bus := events.NewBus()
 	r := &System{
-		ctx:       ctx,
+		// events published by pipelines and sources built using
+		// this context are delivered to the systems event bus
+		ctx:       events.WithPublisher(ctx, bus),
 		meta:      meta,
 		pipelines: map[string]core.Pipeline{},
+		events:    bus,
 	}
 
-	r.server = newServer(r)
-
 	return r
 }
 
@@ -104,7 +112,7 @@ func (s *System) Pipelines() iter.Seq2[string, core.Pipeline] {
 
 // AddPipeline invokes a pipeline builder function provided by the caller.
 // The function is provided with the systems configuration and (if successful)
-// the system registers the resulting pipeline.
+// the resulting pipeline is validated and registered on the system.
 func (s *System) AddPipeline(fn func(context.Context, *Config) (core.Pipeline, error)) *System {
 	// skip next step if error is not nil
 	if s.err != nil {
@@ -123,10 +131,30 @@ func (s *System) AddPipeline(fn func(context.Context, *Config) (core.Pipeline, e
 		return s
 	}
 
+	if err := pipe.Validate(); err != nil {
+		s.err = err
+		return s
+	}
+
+	if _, existing := s.pipelines[pipe.Metadata().Name]; existing {
+		s.err = fmt.Errorf("pipeline %q: %w", pipe.Metadata().Name, core.ErrAlreadyExists)
+		return s
+	}
+
 	s.pipelines[pipe.Metadata().Name] = pipe
 	return s
 }
 
+// Approvals returns the approvals.Store derived from the systems configuration.
+func (s *System) Approvals() (approvals.Store, error) {
+	conf, err := s.configuration()
+	if err != nil {
+		return nil, err
+	}
+
+	return conf.ApprovalStore()
+}
+
 func (s *System) configuration() (_ *Config, err error) {
 	if s.conf != nil {
 		return s.conf, nil
@@ -168,25 +196,79 @@ func (s *System) Run() error {
 		return cli.Run(ctx, s, os.Args...)
 	}
 
+	conf, err := s.configuration()
+	if err != nil {
+		return err
+	}
+
 	var (
-		group errgroup.Group
-		srv   = http.Server{
-			Addr:    ":8080", // TODO: make configurable
-			Handler: s.server,
+		group      errgroup.Group
+		serverConf = conf.conf.Server
+		srv        = http.Server{
+			Addr: serverConf.Address,
+			Handler: newServer(s,
+				withBasePath(serverConf.BasePath),
+				withAuthentication(conf.Authenticators()...),
+				withAuthorizer(conf.Authorizer()),
+				withCORS(serverConf.CORS.AllowedOrigins, serverConf.CORS.AllowCredentials),
+			),
+			ReadTimeout:  serverConf.ReadTimeout,
+			WriteTimeout: serverConf.WriteTimeout,
+			IdleTimeout:  serverConf.IdleTimeout,
+			BaseContext: func(net.Listener) context.Context {
+				// requests inherit the systems event publisher
+				return context.WithoutCancel(ctx)
+			},
 		}
 	)
 
+	if tlsConf := serverConf.TLS; tlsConf != nil {
+		reloader, err := certs.NewReloader(tlsConf.CertFile, tlsConf.KeyFile)
+		if err != nil {
+			return fmt.Errorf("server tls: %w", err)
+		}
+
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		}
+
+		if tlsConf.ClientCAFile != "" {
+			pem, err := os.ReadFile(tlsConf.ClientCAFile)
+			if err != nil {
+				return fmt.Errorf("server tls: %w", err)
+			}
+
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return fmt.Errorf("server tls: no certificates found in %q", tlsConf.ClientCAFile)
+			}
+
+			// client certificates are optional at the TLS layer so that
+			// other authentication methods can be used instead
+			srv.TLSConfig.ClientCAs = pool
+			srv.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+
 	group.Go(func() error {
 		<-ctx.Done()
 
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serverConf.ShutdownGracePeriod)
 		defer cancel()
 		return srv.Shutdown(shutdownCtx)
 	})
 
 	group.Go(func() error {
-		slog.Info("starting server", "addr", ":8080")
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		slog.Info("starting server", "addr", srv.Addr, "base_path", serverConf.BasePath, "tls", srv.TLSConfig != nil)
+
+		serve := srv.ListenAndServe
+		if srv.TLSConfig != nil {
+			// certificates are provided by the TLS configuration
+			serve = func() error { return srv.ListenAndServeTLS("", "") }
+		}
+
+		if err := serve(); err != nil && err != http.ErrServerClosed {
 			cancel()
 			return err
 		}
@@ -194,7 +276,7 @@ func (s *System) Run() error {
 	})
 
 	group.Go(func() error {
-		return s.runTriggers(ctx)
+		return s.runTriggers(ctx, serverConf.ShutdownGracePeriod)
 	})
 
 	return group.Wait()
@@ -218,7 +300,9 @@ func (s *System) AddTrigger(trigger Trigger) *System {
 	return s
 }
 
-func (s *System) runTriggers(ctx context.Context) error {
+// runTriggers runs each trigger until ctx is cancelled and then waits up to
+// grace for them to return.
+func (s *System) runTriggers(ctx context.Context, grace time.Duration) error {
 	var wg sync.WaitGroup
 	for _, trigger := range s.triggers {
 		wg.Add(1)
@@ -238,7 +322,7 @@ func (s *System) runTriggers(ctx context.Context) error {
 	<-ctx.Done()
 
 	select {
-	case <-time.After(15 * time.Second):
+	case <-time.After(grace):
 		return errors.New("timedout waiting on shutdown of schedules")
 	case <-finished:
 		return ctx.Err()
@@ -0,0 +1,106 @@
+package certs
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reloader serves a TLS certificate loaded from a certificate and key file pair.
+// The files are checked for changes on each handshake and the certificate
+// is reloaded when either modification time changes.
+// When a reload fails, the previously loaded certificate continues to be served.
+type Reloader struct {
+	certFile, keyFile string
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	certMod  time.Time
+	keyMod   time.Time
+	lastStat time.Time
+	now      func() time.Time
+}
+
+// NewReloader loads the certificate and key pair and returns a Reloader
+// which serves it via GetCertificate.
+func NewReloader(certFile, keyFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile, now: time.Now}
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.load(certMod, keyMod); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetCertificate can be used as the GetCertificate function on a tls.Config.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if err := r.maybeReload(); err != nil {
+		slog.Error("reloading tls certificate", "error", err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// maybeReload reloads the certificate if the files have changed since
+// they were last loaded. Files are checked at most once per second.
+func (r *Reloader) maybeReload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if now.Sub(r.lastStat) < time.Second {
+		return nil
+	}
+
+	r.lastStat = now
+
+	certMod, keyMod, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+
+	if certMod.Equal(r.certMod) && keyMod.Equal(r.keyMod) {
+		return nil
+	}
+
+	return r.load(certMod, keyMod)
+}
+
+func (r *Reloader) load(certMod, keyMod time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("loading certificate: %w", err)
+	}
+
+	r.cert, r.certMod, r.keyMod = &cert, certMod, keyMod
+
+	slog.Debug("loaded tls certificate", "cert", r.certFile, "key", r.keyFile)
+
+	return nil
+}
+
+func (r *Reloader) modTimes() (cert, key time.Time, err error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return cert, key, err
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return cert, key, err
+	}
+
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
@@ -51,6 +51,9 @@ func (d *Decoder[C]) Decode(c *C) error {
 			return strings.EqualFold(stripUnderscore(mapKey), stripUnderscore(fieldName))
 		},
 		DecodeHook: mapstructure.ComposeDecodeHookFunc(
+			// durations must be decoded before the generic int64 hook below
+			// as time.Duration is itself an int64 kind
+			mapstructure.StringToTimeDurationHookFunc(),
 			mapstructure.DecodeHookFuncType(func(from, to reflect.Type, i interface{}) (interface{}, error) {
 				if from.Kind() != reflect.String {
 					return i, nil
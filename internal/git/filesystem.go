@@ -34,6 +34,7 @@ type filesystem struct {
 
 	sigName  string
 	sigEmail string
+	signer   Signer
 }
 
 // ReadDir reads the directory named by dirname and returns a list of
@@ -438,30 +439,46 @@ func updatePath(logger *slog.Logger, storage gitstorage.Storer, node *object.Tre
 	return node.Decode(obj)
 }
 
-func (f *filesystem) commit(_ context.Context, msg string) (*object.Commit, error) {
+// commit creates a commit for the current tree with the repositories signature as committer.
+// When an author name is provided, then it is used as the commit author (with the email when non-empty).
+func (f *filesystem) commit(_ context.Context, msg, authorName, authorEmail string) (*object.Commit, error) {
 	if f.base.TreeHash == f.tree.Hash {
 		return nil, ErrEmptyCommit
 	}
 
-	signature := object.Signature{
+	committer := object.Signature{
 		Name:  f.sigName,
 		Email: f.sigEmail,
 		When:  time.Now().UTC(),
 	}
 
+	author := committer
+	if authorName != "" {
+		author.Name = authorName
+		if authorEmail != "" {
+			author.Email = authorEmail
+		}
+	}
+
 	var hashes []plumbing.Hash
 	if f.base != nil {
 		hashes = []plumbing.Hash{f.base.Hash}
 	}
 
 	commit := &object.Commit{
-		Author:       signature,
-		Committer:    signature,
+		Author:       author,
+		Committer:    committer,
 		Message:      msg,
 		TreeHash:     f.tree.Hash,
 		ParentHashes: hashes,
 	}
 
+	if f.signer != nil {
+		if err := sign(f.signer, commit); err != nil {
+			return nil, err
+		}
+	}
+
 	obj := f.storage.NewEncodedObject()
 	err := commit.Encode(obj)
 	if err != nil {
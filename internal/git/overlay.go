@@ -0,0 +1,56 @@
+package git
+
+import (
+	"errors"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	gitstorage "github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// overlayStorer is a gitstorage.Storer which writes objects to an in-memory scratch storage
+// and reads through to the underlying storer for any objects it does not contain.
+// It allows changes to be rendered (e.g. for a diff) without writing to shared storage.
+type overlayStorer struct {
+	gitstorage.Storer
+
+	scratch *memory.Storage
+}
+
+func newOverlayStorer(base gitstorage.Storer) *overlayStorer {
+	return &overlayStorer{Storer: base, scratch: memory.NewStorage()}
+}
+
+func (o *overlayStorer) NewEncodedObject() plumbing.EncodedObject {
+	return o.scratch.NewEncodedObject()
+}
+
+func (o *overlayStorer) SetEncodedObject(obj plumbing.EncodedObject) (plumbing.Hash, error) {
+	return o.scratch.SetEncodedObject(obj)
+}
+
+func (o *overlayStorer) EncodedObject(typ plumbing.ObjectType, hash plumbing.Hash) (plumbing.EncodedObject, error) {
+	obj, err := o.scratch.EncodedObject(typ, hash)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return o.Storer.EncodedObject(typ, hash)
+	}
+
+	return obj, err
+}
+
+func (o *overlayStorer) HasEncodedObject(hash plumbing.Hash) error {
+	if err := o.scratch.HasEncodedObject(hash); err == nil {
+		return nil
+	}
+
+	return o.Storer.HasEncodedObject(hash)
+}
+
+func (o *overlayStorer) EncodedObjectSize(hash plumbing.Hash) (int64, error) {
+	size, err := o.scratch.EncodedObjectSize(hash)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		return o.Storer.EncodedObjectSize(hash)
+	}
+
+	return size, err
+}
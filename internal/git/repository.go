@@ -39,6 +39,7 @@ type Repository struct {
 	readme          []byte
 	sigName         string
 	sigEmail        string
+	signer          Signer
 	maxOpenDescs    int
 
 	mu   sync.RWMutex
@@ -195,10 +196,14 @@ func (r *Repository) startPolling(ctx context.Context) {
 		return
 	}
 
+	ctx, r.cancel = context.WithCancel(ctx)
+
 	go func() {
 		defer close(r.done)
 
 		ticker := time.NewTicker(r.pollInterval)
+		defer ticker.Stop()
+
 		for {
 			select {
 			case <-ctx.Done():
@@ -358,6 +363,10 @@ type ViewUpdateOptions struct {
 	branch   string
 	revision *plumbing.Hash
 	force    bool
+	onto     string
+
+	authorName  string
+	authorEmail string
 }
 
 func (r *Repository) getOptions(opts ...containers.Option[ViewUpdateOptions]) *ViewUpdateOptions {
@@ -382,6 +391,25 @@ func WithForce(vuo *ViewUpdateOptions) {
 	vuo.force = true
 }
 
+// WithRebaseOnto causes UpdateAndPush to build the update on the head of the
+// provided branch, instead of the target branch, and force push the result to the target.
+// This discards any existing commits on the target branch.
+func WithRebaseOnto(branch string) containers.Option[ViewUpdateOptions] {
+	return func(vuo *ViewUpdateOptions) {
+		vuo.onto = branch
+		vuo.force = true
+	}
+}
+
+// WithAuthor sets the author of commits made by an update.
+// The repositories signature is used for the committer and in place of an empty author.
+func WithAuthor(name, email string) containers.Option[ViewUpdateOptions] {
+	return func(vuo *ViewUpdateOptions) {
+		vuo.authorName = name
+		vuo.authorEmail = email
+	}
+}
+
 func (r *Repository) View(ctx context.Context, fn func(hash plumbing.Hash, fs fs.Filesystem) error, opts ...containers.Option[ViewUpdateOptions]) (err error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -390,12 +418,18 @@ func (r *Repository) View(ctx context.Context, fn func(hash plumbing.Hash, fs fs
 
 	r.logger.Debug("View", slog.String("branch", options.branch))
 
-	hash, err := r.Resolve(options.branch)
-	if err != nil {
-		return err
+	var hash plumbing.Hash
+	if options.revision != nil {
+		// an explicit revision takes precedence over the branch head
+		hash = *options.revision
+	} else {
+		hash, err = r.Resolve(options.branch)
+		if err != nil {
+			return err
+		}
 	}
 
-	fs, err := r.newFilesystem(hash)
+	fs, err := r.newFilesystem(r.repo.Storer, hash)
 	if err != nil {
 		return err
 	}
@@ -403,6 +437,56 @@ func (r *Repository) View(ctx context.Context, fn func(hash plumbing.Hash, fs fs
 	return fn(hash, fs)
 }
 
+// Diff applies fn to a filesystem based on the head of the target branch and returns
+// a unified diff of the resulting changes.
+// The changes are neither committed nor pushed, and their objects are only written to a scratch overlay.
+func (r *Repository) Diff(ctx context.Context, fn func(fs fs.Filesystem) error, opts ...containers.Option[ViewUpdateOptions]) (_ string, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	options := r.getOptions(opts...)
+
+	r.logger.Debug("Diff", slog.String("branch", options.branch))
+
+	hash, err := r.Resolve(options.branch)
+	if err != nil {
+		return "", err
+	}
+
+	// render changes into a scratch overlay so that concurrent
+	// readers of the shared storage are not written to
+	fs, err := r.newFilesystem(newOverlayStorer(r.repo.Storer), hash)
+	if err != nil {
+		return "", err
+	}
+
+	// fetch an independent copy of the base tree as the filesystem
+	// mutates its own tree in-place
+	base := &object.Tree{}
+	if fs.base != nil {
+		base, err = fs.base.Tree()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if err := fn(fs); err != nil {
+		return "", err
+	}
+
+	changes, err := object.DiffTreeWithOptions(ctx, base, fs.tree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return "", err
+	}
+
+	patch, err := changes.PatchContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return patch.String(), nil
+}
+
 func (r *Repository) UpdateAndPush(ctx context.Context, fn func(fs fs.Filesystem) (string, error), opts ...containers.Option[ViewUpdateOptions]) (hash plumbing.Hash, err error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -413,7 +497,12 @@ func (r *Repository) UpdateAndPush(ctx context.Context, fn func(fs fs.Filesystem
 		rev     = options.revision
 	)
 
-	hash, err = r.Resolve(branch)
+	base := branch
+	if options.onto != "" {
+		base = options.onto
+	}
+
+	hash, err = r.Resolve(base)
 	if err != nil {
 		return plumbing.ZeroHash, err
 	}
@@ -425,7 +514,7 @@ func (r *Repository) UpdateAndPush(ctx context.Context, fn func(fs fs.Filesystem
 	}
 
 	// if rev == nil then hash will be the zero hash
-	fs, err := r.newFilesystem(hash)
+	fs, err := r.newFilesystem(r.repo.Storer, hash)
 	if err != nil {
 		return hash, err
 	}
@@ -435,7 +524,7 @@ func (r *Repository) UpdateAndPush(ctx context.Context, fn func(fs fs.Filesystem
 		return hash, err
 	}
 
-	commit, err := fs.commit(ctx, msg)
+	commit, err := fs.commit(ctx, msg, options.authorName, options.authorEmail)
 	if err != nil {
 		return hash, err
 	}
@@ -557,7 +646,42 @@ func (r *Repository) CreateBranchIfNotExists(branch string, opts ...containers.O
 		reference.Hash()))
 }
 
-func (r *Repository) newFilesystem(hash plumbing.Hash) (_ *filesystem, err error) {
+// DeleteBranch removes the branch from the configured remote (if any)
+// along with the local and remote tracking references.
+func (r *Repository) DeleteBranch(ctx context.Context, branch string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remoteName := "origin"
+	if r.remote != nil {
+		remoteName = r.remote.Name
+
+		if err := r.repo.PushContext(ctx, &git.PushOptions{
+			RemoteName:      r.remote.Name,
+			Auth:            r.auth,
+			CABundle:        r.caBundle,
+			InsecureSkipTLS: r.insecureSkipTLS,
+			RefSpecs: []config.RefSpec{
+				config.RefSpec(":" + plumbing.NewBranchReferenceName(branch)),
+			},
+		}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return err
+		}
+	}
+
+	for _, ref := range []plumbing.ReferenceName{
+		plumbing.NewBranchReferenceName(branch),
+		plumbing.NewRemoteReferenceName(remoteName, branch),
+	} {
+		if err := r.repo.Storer.RemoveReference(ref); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) newFilesystem(storer storage.Storer, hash plumbing.Hash) (_ *filesystem, err error) {
 	var (
 		commit *object.Commit
 		tree   = &object.Tree{}
@@ -567,7 +691,7 @@ func (r *Repository) newFilesystem(hash plumbing.Hash) (_ *filesystem, err error
 	// the caller needs to validate whether this is true or not
 	// before calling newFilesystem with zero hash
 	if hash != plumbing.ZeroHash {
-		commit, err = r.repo.CommitObject(hash)
+		commit, err = object.GetCommit(storer, hash)
 		if err != nil {
 			return nil, fmt.Errorf("getting branch commit: %w", err)
 		}
@@ -582,9 +706,10 @@ func (r *Repository) newFilesystem(hash plumbing.Hash) (_ *filesystem, err error
 		logger:   r.logger,
 		base:     commit,
 		tree:     tree,
-		storage:  r.repo.Storer,
+		storage:  storer,
 		sigName:  r.sigName,
 		sigEmail: r.sigEmail,
+		signer:   r.signer,
 	}, nil
 }
 
@@ -641,8 +766,8 @@ func WithFilesystemStorage(path string) containers.Option[Repository] {
 	}
 }
 
-// WithSignature sets the default signature name and email when the signature
-// cannot be derived from the request context.
+// WithSignature sets the committer signature name and email.
+// It is also used as the author when no core.Actor can be derived from the request context.
 func WithSignature(name, email string) containers.Option[Repository] {
 	return func(r *Repository) {
 		r.sigName = name
@@ -0,0 +1,43 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/get-glu/glu/pkg/containers"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Signer produces the signature for an encoded git object.
+// The resulting signature is stored in the objects gpgsig header.
+type Signer = git.Signer
+
+// WithSigner configures the repository to sign each commit it creates.
+func WithSigner(signer Signer) containers.Option[Repository] {
+	return func(r *Repository) {
+		r.signer = signer
+	}
+}
+
+// sign computes and sets the signature for the provided commit.
+func sign(signer Signer, commit *object.Commit) error {
+	encoded := &plumbing.MemoryObject{}
+	if err := commit.EncodeWithoutSignature(encoded); err != nil {
+		return err
+	}
+
+	rd, err := encoded.Reader()
+	if err != nil {
+		return err
+	}
+
+	sig, err := signer.Sign(rd)
+	if err != nil {
+		return fmt.Errorf("signing commit: %w", err)
+	}
+
+	commit.PGPSignature = string(sig)
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package helm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/get-glu/glu/internal/semver"
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/credentials"
+	"github.com/get-glu/glu/pkg/src/helm"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"gopkg.in/yaml.v3"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry/remote"
+)
+
+var _ helm.Resolver = (*Repository)(nil)
+
+// Repository resolves the latest version of a chart, which matches a semver constraint,
+// from either a classic HTTP chart repository (index.yaml) or an OCI registry (oci://).
+type Repository struct {
+	chart      string
+	constraint *semver.Constraint
+
+	// index is the location of the index.yaml for HTTP repositories
+	index  string
+	client *http.Client
+
+	// oci is the chart repository for OCI registries
+	oci *remote.Repository
+}
+
+// New constructs a chart Repository for the named chart hosted at repoURL.
+// An empty constraint matches the latest stable version.
+func New(ctx context.Context, repoURL, chart, constraint string, cred *credentials.Credential) (_ *Repository, err error) {
+	r := &Repository{chart: chart, client: http.DefaultClient}
+
+	r.constraint, err = semver.ParseConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	if reference, ok := strings.CutPrefix(repoURL, "oci://"); ok {
+		r.oci, err = remote.NewRepository(strings.TrimSuffix(reference, "/") + "/" + chart)
+		if err != nil {
+			return nil, err
+		}
+
+		if cred != nil {
+			r.oci.Client, err = cred.OCIClient(r.oci.Reference.Registry)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return r, nil
+	}
+
+	r.index, err = url.JoinPath(repoURL, "index.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	if cred != nil {
+		r.client, err = cred.HTTPClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Resolve returns the latest version of the chart which matches the constraint.
+func (r *Repository) Resolve(ctx context.Context) (helm.Chart, error) {
+	if r.oci != nil {
+		return r.resolveOCI(ctx)
+	}
+
+	return r.resolveIndex(ctx)
+}
+
+type indexFile struct {
+	Entries map[string][]struct {
+		Version    string `yaml:"version"`
+		AppVersion string `yaml:"appVersion"`
+		Digest     string `yaml:"digest"`
+	} `yaml:"entries"`
+}
+
+func (r *Repository) resolveIndex(ctx context.Context) (helm.Chart, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.index, nil)
+	if err != nil {
+		return helm.Chart{}, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return helm.Chart{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return helm.Chart{}, fmt.Errorf("fetching %s: unexpected status %s", r.index, resp.Status)
+	}
+
+	var index indexFile
+	if err := yaml.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return helm.Chart{}, fmt.Errorf("decoding %s: %w", r.index, err)
+	}
+
+	entries := index.Entries[r.chart]
+
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		versions = append(versions, entry.Version)
+	}
+
+	version, err := r.latest(versions)
+	if err != nil {
+		return helm.Chart{}, err
+	}
+
+	for _, entry := range entries {
+		if entry.Version != version {
+			continue
+		}
+
+		digest := entry.Digest
+		if digest != "" && !strings.Contains(digest, ":") {
+			// index digests are hex encoded sha256 sums of the chart archive
+			digest = "sha256:" + digest
+		}
+
+		return helm.Chart{
+			Name:       r.chart,
+			Version:    entry.Version,
+			AppVersion: entry.AppVersion,
+			Digest:     digest,
+		}, nil
+	}
+
+	return helm.Chart{}, fmt.Errorf("chart %q version %q: %w", r.chart, version, core.ErrNotFound)
+}
+
+func (r *Repository) resolveOCI(ctx context.Context) (helm.Chart, error) {
+	// helm substitutes "+" (build metadata) with "_" as it is not valid in OCI tags
+	tags := map[string]string{}
+	if err := r.oci.Tags(ctx, "", func(page []string) error {
+		for _, tag := range page {
+			tags[strings.ReplaceAll(tag, "_", "+")] = tag
+		}
+
+		return nil
+	}); err != nil {
+		return helm.Chart{}, err
+	}
+
+	versions := make([]string, 0, len(tags))
+	for version := range tags {
+		versions = append(versions, version)
+	}
+
+	version, err := r.latest(versions)
+	if err != nil {
+		return helm.Chart{}, err
+	}
+
+	desc, err := r.oci.Resolve(ctx, tags[version])
+	if err != nil {
+		return helm.Chart{}, err
+	}
+
+	data, err := content.FetchAll(ctx, r.oci, desc)
+	if err != nil {
+		return helm.Chart{}, err
+	}
+
+	var manifest v1.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return helm.Chart{}, fmt.Errorf("decoding chart manifest: %w", err)
+	}
+
+	// the config blob is the charts Chart.yaml encoded as JSON
+	data, err = content.FetchAll(ctx, r.oci, manifest.Config)
+	if err != nil {
+		return helm.Chart{}, err
+	}
+
+	var meta struct {
+		Name       string `json:"name"`
+		Version    string `json:"version"`
+		AppVersion string `json:"appVersion"`
+	}
+
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return helm.Chart{}, fmt.Errorf("decoding chart config: %w", err)
+	}
+
+	return helm.Chart{
+		Name:       meta.Name,
+		Version:    meta.Version,
+		AppVersion: meta.AppVersion,
+		Digest:     desc.Digest.String(),
+	}, nil
+}
+
+func (r *Repository) latest(versions []string) (string, error) {
+	version, ok := r.constraint.Latest(versions)
+	if !ok {
+		return "", fmt.Errorf("chart %q: no version matching %q: %w", r.chart, r.constraint, core.ErrNotFound)
+	}
+
+	return version, nil
+}
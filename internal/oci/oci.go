@@ -1,21 +1,40 @@
 package oci
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/get-glu/glu/internal/semver"
 	"github.com/get-glu/glu/pkg/config"
+	"github.com/get-glu/glu/pkg/core"
 	"github.com/get-glu/glu/pkg/credentials"
+	"github.com/get-glu/glu/pkg/src/oci"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
 	"oras.land/oras-go/v2/registry/remote"
 )
 
+var _ oci.Fetcher = (*Repository)(nil)
+
 type Repository struct {
 	repo *remote.Repository
 	conf config.OCIRepository
+
+	// constraint and pattern select from the repositories tags when configured
+	constraint *semver.Constraint
+	pattern    *regexp.Regexp
+
+	// keys verify signatures when verification is configured
+	keys []publicKey
 }
 
-func New(reference string, cred *credentials.Credential) (_ *Repository, err error) {
-	repo, err := remote.NewRepository(reference)
+func New(conf config.OCIRepository, cred *credentials.Credential) (_ *Repository, err error) {
+	repo, err := remote.NewRepository(conf.Reference)
 	if err != nil {
 		return nil, err
 	}
@@ -27,9 +46,150 @@ func New(reference string, cred *credentials.Credential) (_ *Repository, err err
 		}
 	}
 
-	return &Repository{repo: repo}, nil
+	r := &Repository{repo: repo, conf: conf}
+	if tags := conf.Tags; tags != nil {
+		r.constraint, err = semver.ParseConstraint(tags.Semver)
+		if err != nil {
+			return nil, err
+		}
+
+		if tags.Prerelease {
+			r.constraint = r.constraint.WithPrerelease()
+		}
+
+		r.pattern, err = regexp.Compile(tags.Pattern)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if conf.Verify != nil {
+		if r.keys, err = parsePublicKeys(conf.Verify); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
 }
 
+// Resolve resolves the configured reference or, when tag selection is configured,
+// the highest matching tag. The resolved tag is recorded on the returned descriptor
+// as the org.opencontainers.image.ref.name annotation.
 func (r *Repository) Resolve(ctx context.Context) (v1.Descriptor, error) {
-	return r.repo.Resolve(ctx, r.repo.Reference.ReferenceOrDefault())
+	tag := r.repo.Reference.ReferenceOrDefault()
+	if r.conf.Tags != nil {
+		var err error
+		if tag, err = r.selectTag(ctx); err != nil {
+			return v1.Descriptor{}, err
+		}
+	}
+
+	desc, err := r.repo.Resolve(ctx, tag)
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+
+	annotations := maps.Clone(desc.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[v1.AnnotationRefName] = tag
+	desc.Annotations = annotations
+
+	return desc, nil
+}
+
+// Fetch fetches and verifies the content of the provided descriptor from the repository.
+func (r *Repository) Fetch(ctx context.Context, desc v1.Descriptor) ([]byte, error) {
+	return content.FetchAll(ctx, r.repo, desc)
+}
+
+// selectTag lists the repositories tags and returns the highest which matches
+// both the pattern and semver constraint.
+// When no semver constraint is configured and no matching tag parses as semver,
+// then the greatest matching tag in natural order (e.g. build-10 > build-9) is returned.
+func (r *Repository) selectTag(ctx context.Context) (string, error) {
+	var matched []string
+	if err := r.repo.Tags(ctx, "", func(tags []string) error {
+		for _, tag := range tags {
+			if r.pattern.MatchString(tag) {
+				matched = append(matched, tag)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return "", fmt.Errorf("listing tags: %w", err)
+	}
+
+	if tag, ok := r.constraint.Latest(matched); ok {
+		return tag, nil
+	}
+
+	if r.conf.Tags.Semver == "" && len(matched) > 0 && !slices.ContainsFunc(matched, isSemver) {
+		return slices.MaxFunc(matched, naturalCompare), nil
+	}
+
+	return "", fmt.Errorf("no tag matching %s: %w", r.describe(), core.ErrNotFound)
+}
+
+func (r *Repository) describe() string {
+	var parts []string
+	if tags := r.conf.Tags; tags != nil {
+		if tags.Semver != "" {
+			parts = append(parts, fmt.Sprintf("semver %q", tags.Semver))
+		}
+
+		if tags.Pattern != "" {
+			parts = append(parts, fmt.Sprintf("pattern %q", tags.Pattern))
+		}
+	}
+
+	return fmt.Sprintf("%s in %s", strings.Join(parts, " and "), r.repo.Reference.Repository)
+}
+
+func isSemver(tag string) bool {
+	_, err := semver.Parse(tag)
+	return err == nil
+}
+
+// naturalCompare compares strings lexically except that runs of digits
+// are compared by their numeric value.
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		ad, bd := digits(a), digits(b)
+		if ad == 0 || bd == 0 {
+			if a[0] != b[0] {
+				return cmp.Compare(a[0], b[0])
+			}
+
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		// compare numeric runs by length (ignoring leading zeros) and then lexically
+		an, bn := strings.TrimLeft(a[:ad], "0"), strings.TrimLeft(b[:bd], "0")
+		if c := cmp.Compare(len(an), len(bn)); c != 0 {
+			return c
+		}
+
+		if c := strings.Compare(an, bn); c != 0 {
+			return c
+		}
+
+		a, b = a[ad:], b[bd:]
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+// digits returns the length of the run of ASCII digits at the start of s.
+func digits(s string) int {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+
+	return i
 }
@@ -0,0 +1,334 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/get-glu/glu/pkg/config"
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/src/oci"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/errdef"
+)
+
+var _ oci.Verifier = (*Repository)(nil)
+
+const (
+	mediaTypeSimpleSigning = "application/vnd.dev.cosign.simplesigning.v1+json"
+	mediaTypeDSSE          = "application/vnd.dsse.envelope.v1+json"
+
+	annotationSignature = "dev.cosignproject.cosign/signature"
+)
+
+// publicKey is a named key which signatures are verified against.
+type publicKey struct {
+	name string
+	key  crypto.PublicKey
+}
+
+func parsePublicKeys(conf *config.OCIVerify) ([]publicKey, error) {
+	keys := make([]publicKey, 0, len(conf.Keys))
+	for _, k := range conf.Keys {
+		data := []byte(k.PublicKeyBytes)
+		if k.PublicKeyPath != "" {
+			var err error
+			if data, err = os.ReadFile(k.PublicKeyPath); err != nil {
+				return nil, fmt.Errorf("key %q: %w", k.Name, err)
+			}
+		}
+
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("key %q: no PEM block found", k.Name)
+		}
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", k.Name, err)
+		}
+
+		switch key.(type) {
+		case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		default:
+			return nil, fmt.Errorf("key %q: unsupported key type %T", k.Name, key)
+		}
+
+		keys = append(keys, publicKey{name: k.Name, key: key})
+	}
+
+	return keys, nil
+}
+
+// verify returns true if sig is a valid signature of message by the key.
+// ECDSA and RSA (PKCS #1 v1.5) signatures are over the SHA-256 digest of the message
+// as produced by cosign.
+func (k publicKey) verify(message, sig []byte) bool {
+	digest := sha256.Sum256(message)
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, sig)
+	}
+
+	return false
+}
+
+// signers returns the names of the keys which produced sig over message.
+func (r *Repository) signers(message, sig []byte) (names []string) {
+	for _, key := range r.keys {
+		if key.verify(message, sig) {
+			names = append(names, key.name)
+		}
+	}
+
+	return
+}
+
+// Verify discovers cosign style signatures and attestations for the descriptor, from both the
+// sha256-<digest>.sig and .att tags and the registries referrers, and verifies them against
+// the configured keys. It returns nil when verification is not configured.
+// Signatures which cannot be verified are ignored, they are reported as unverified
+// only when no signature by a configured key is found.
+func (r *Repository) Verify(ctx context.Context, desc v1.Descriptor) (*core.Verification, error) {
+	if r.conf.Verify == nil {
+		return nil, nil
+	}
+
+	var (
+		tag = strings.Replace(desc.Digest.String(), ":", "-", 1)
+		s   = &signatures{subject: desc}
+	)
+
+	for _, suffix := range []string{".sig", ".att"} {
+		manifest, err := r.fetchManifest(ctx, tag+suffix)
+		if err != nil {
+			if errors.Is(err, errdef.ErrNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("fetching %s: %w", tag+suffix, err)
+		}
+
+		r.verifyManifest(ctx, s, manifest)
+	}
+
+	if err := r.repo.Referrers(ctx, desc, "", func(referrers []v1.Descriptor) error {
+		for _, referrer := range referrers {
+			manifest, err := r.fetchManifest(ctx, referrer.Digest.String())
+			if err != nil {
+				slog.Debug("skipping referrer", "digest", referrer.Digest, "error", err)
+				continue
+			}
+
+			r.verifyManifest(ctx, s, manifest)
+		}
+
+		return nil
+	}); err != nil {
+		slog.Debug("listing referrers", "digest", desc.Digest, "error", err)
+	}
+
+	verification := &core.Verification{
+		Digest:       desc.Digest.String(),
+		Signers:      slices.Compact(slices.Sorted(slices.Values(s.signers))),
+		Attestations: slices.Compact(slices.Sorted(slices.Values(s.attestations))),
+	}
+
+	switch {
+	case len(verification.Signers) == 0:
+		verification.Reason = "no signature by a trusted key"
+	default:
+		var missing []string
+		for _, predicate := range r.conf.Verify.Attestations {
+			if !slices.Contains(verification.Attestations, predicate) {
+				missing = append(missing, predicate)
+			}
+		}
+
+		if len(missing) > 0 {
+			verification.Reason = fmt.Sprintf("missing attestations: %s", strings.Join(missing, ", "))
+			break
+		}
+
+		verification.Verified = true
+	}
+
+	return verification, nil
+}
+
+// signatures accumulates the verified signers and attested predicate types for a subject.
+type signatures struct {
+	subject      v1.Descriptor
+	signers      []string
+	attestations []string
+}
+
+func (r *Repository) fetchManifest(ctx context.Context, reference string) (*v1.Manifest, error) {
+	desc, err := r.repo.Resolve(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := content.FetchAll(ctx, r.repo, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest v1.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// verifyManifest verifies each signature and attestation layer in the manifest
+// and records the results on s. Layers which fail to verify are skipped.
+func (r *Repository) verifyManifest(ctx context.Context, s *signatures, manifest *v1.Manifest) {
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != mediaTypeSimpleSigning && layer.MediaType != mediaTypeDSSE {
+			continue
+		}
+
+		payload, err := content.FetchAll(ctx, r.repo, layer)
+		if err != nil {
+			slog.Debug("fetching signature layer", "digest", layer.Digest, "error", err)
+			continue
+		}
+
+		switch layer.MediaType {
+		case mediaTypeSimpleSigning:
+			signers, err := r.verifySimpleSigning(s.subject, layer, payload)
+			if err != nil {
+				slog.Debug("skipping signature", "digest", layer.Digest, "error", err)
+				continue
+			}
+
+			s.signers = append(s.signers, signers...)
+		case mediaTypeDSSE:
+			signers, predicate, err := r.verifyDSSE(s.subject, payload)
+			if err != nil {
+				slog.Debug("skipping attestation", "digest", layer.Digest, "error", err)
+				continue
+			}
+
+			// a signed attestation also counts as a signature of the subject
+			s.signers = append(s.signers, signers...)
+			s.attestations = append(s.attestations, predicate)
+		}
+	}
+}
+
+// verifySimpleSigning verifies a cosign signature layer, where the signature is the layers
+// annotation and the payload is a simple signing document naming the signed digest.
+func (r *Repository) verifySimpleSigning(subject, layer v1.Descriptor, payload []byte) ([]string, error) {
+	sig, err := base64.StdEncoding.DecodeString(layer.Annotations[annotationSignature])
+	if err != nil || len(sig) == 0 {
+		return nil, errors.New("missing or malformed signature annotation")
+	}
+
+	var document struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+
+	if err := json.Unmarshal(payload, &document); err != nil {
+		return nil, fmt.Errorf("decoding payload: %w", err)
+	}
+
+	if digest := document.Critical.Image.DockerManifestDigest; digest != subject.Digest.String() {
+		return nil, fmt.Errorf("payload signs %q", digest)
+	}
+
+	signers := r.signers(payload, sig)
+	if len(signers) == 0 {
+		return nil, errors.New("not signed by a trusted key")
+	}
+
+	return signers, nil
+}
+
+// verifyDSSE verifies a DSSE envelope containing an in-toto statement about the subject
+// and returns the signers and the statements predicate type.
+func (r *Repository) verifyDSSE(subject v1.Descriptor, data []byte) ([]string, string, error) {
+	var envelope struct {
+		PayloadType string `json:"payloadType"`
+		Payload     string `json:"payload"`
+		Signatures  []struct {
+			Sig string `json:"sig"`
+		} `json:"signatures"`
+	}
+
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, "", fmt.Errorf("decoding envelope: %w", err)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding payload: %w", err)
+	}
+
+	var signers []string
+	for _, signature := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+
+		signers = append(signers, r.signers(pae(envelope.PayloadType, payload), sig)...)
+	}
+
+	if len(signers) == 0 {
+		return nil, "", errors.New("not signed by a trusted key")
+	}
+
+	var statement struct {
+		PredicateType string `json:"predicateType"`
+		Subject       []struct {
+			Digest map[string]string `json:"digest"`
+		} `json:"subject"`
+	}
+
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, "", fmt.Errorf("decoding statement: %w", err)
+	}
+
+	algorithm, encoded, _ := strings.Cut(subject.Digest.String(), ":")
+	for _, s := range statement.Subject {
+		if s.Digest[algorithm] == encoded {
+			return signers, statement.PredicateType, nil
+		}
+	}
+
+	return nil, "", errors.New("statement does not name the subject")
+}
+
+// pae returns the DSSE pre-authentication encoding of the payload.
+func pae(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buf.Write(payload)
+	return buf.Bytes()
+}
@@ -0,0 +1,467 @@
+// Package semver parses semantic versions (https://semver.org) and evaluates
+// version constraints in the style of npm and Helm (e.g. ">=1.2.0 <2.0.0", "~1.4", "^0.3.1 || 2.x").
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version.
+type Version struct {
+	Major, Minor, Patch uint64
+	Prerelease          []string
+	Metadata            string
+
+	original string
+}
+
+// Parse parses a complete semantic version with an optional leading "v".
+func Parse(s string) (*Version, error) {
+	v, parts, err := parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if parts < 3 {
+		return nil, fmt.Errorf("version %q: expected major.minor.patch", s)
+	}
+
+	return v, nil
+}
+
+// parse parses a possibly partial version (e.g. "1", "1.2" or "1.2.x") and returns
+// the number of leading numeric parts present.
+func parse(s string) (_ *Version, parts int, err error) {
+	v := &Version{original: s}
+
+	rest := strings.TrimPrefix(s, "v")
+	rest, v.Metadata, _ = strings.Cut(rest, "+")
+
+	rest, pre, hasPre := strings.Cut(rest, "-")
+	if hasPre {
+		if pre == "" {
+			return nil, 0, fmt.Errorf("version %q: empty pre-release", s)
+		}
+
+		v.Prerelease = strings.Split(pre, ".")
+	}
+
+	fields := strings.Split(rest, ".")
+	if len(fields) > 3 {
+		return nil, 0, fmt.Errorf("version %q: too many parts", s)
+	}
+
+	nums := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, field := range fields {
+		if isWildcard(field) {
+			break
+		}
+
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("version %q: invalid number %q", s, field)
+		}
+
+		*nums[i] = n
+		parts++
+	}
+
+	if parts < 3 && hasPre {
+		return nil, 0, fmt.Errorf("version %q: pre-release requires major.minor.patch", s)
+	}
+
+	return v, parts, nil
+}
+
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*"
+}
+
+// String returns the version as it was originally parsed.
+func (v *Version) String() string {
+	if v.original != "" {
+		return v.original
+	}
+
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+
+	if v.Metadata != "" {
+		s += "+" + v.Metadata
+	}
+
+	return s
+}
+
+// Compare returns -1, 0 or +1 depending on whether v is less than, equal to or greater than o.
+// Build metadata is ignored.
+func (v *Version) Compare(o *Version) int {
+	for _, c := range [][2]uint64{{v.Major, o.Major}, {v.Minor, o.Minor}, {v.Patch, o.Patch}} {
+		if c[0] != c[1] {
+			if c[0] < c[1] {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	// a version without a pre-release has higher precedence
+	switch {
+	case len(v.Prerelease) == 0 && len(o.Prerelease) == 0:
+		return 0
+	case len(v.Prerelease) == 0:
+		return 1
+	case len(o.Prerelease) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(o.Prerelease); i++ {
+		if c := compareIdentifier(v.Prerelease[i], o.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(v.Prerelease) < len(o.Prerelease):
+		return -1
+	case len(v.Prerelease) > len(o.Prerelease):
+		return 1
+	}
+
+	return 0
+}
+
+// compareIdentifier compares pre-release identifiers where numeric identifiers
+// have lower precedence than alphanumeric ones.
+func compareIdentifier(a, b string) int {
+	an, aerr := strconv.ParseUint(a, 10, 64)
+	bn, berr := strconv.ParseUint(b, 10, 64)
+
+	switch {
+	case aerr == nil && berr == nil:
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		}
+
+		return 0
+	case aerr == nil:
+		return -1
+	case berr == nil:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// Constraint is a set of alternative comparator groups.
+// A version satisfies the constraint if it satisfies every comparator in any one group.
+type Constraint struct {
+	groups     [][]comparator
+	prerelease bool
+
+	original string
+}
+
+type comparator struct {
+	op      string
+	version *Version
+	// upper (when non-nil) bounds the range [version, upper) excluded by a partial "!=" (e.g. !=1.2)
+	upper *Version
+}
+
+func (c comparator) check(v *Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "!=":
+		if c.upper != nil {
+			return cmp < 0 || v.Compare(c.upper) >= 0
+		}
+
+		return cmp != 0
+	}
+
+	return cmp == 0
+}
+
+// ParseConstraint parses a constraint expression.
+// Comparators separated by whitespace or commas must all be satisfied and groups
+// separated by "||" are alternatives. Supported comparators are =, !=, >, >=, <, <=,
+// tilde (~1.2: >=1.2.0 <1.3.0), caret (^1.2: >=1.2.0 <2.0.0), hyphen ranges (1.2 - 1.4)
+// and wildcards (1.2.x, *). An empty expression matches any version.
+//
+// Pre-release versions only satisfy a group which contains a comparator on a
+// pre-release of the same major.minor.patch.
+func ParseConstraint(s string) (*Constraint, error) {
+	c := &Constraint{original: s}
+
+	for _, group := range strings.Split(s, "||") {
+		comparators, err := parseGroup(group)
+		if err != nil {
+			return nil, fmt.Errorf("constraint %q: %w", s, err)
+		}
+
+		c.groups = append(c.groups, comparators)
+	}
+
+	return c, nil
+}
+
+const operators = "=<>!~^"
+
+// parseGroup parses the whitespace (or comma) separated comparators of a single group.
+// Operators may be separated from their versions (e.g. ">= 1.2") and a hyphen between
+// two versions without operators describes an inclusive range (e.g. "1.2 - 1.4").
+func parseGroup(group string) (comparators []comparator, err error) {
+	var terms []string
+	for _, field := range strings.Fields(strings.ReplaceAll(group, ",", " ")) {
+		// join the version onto a preceding standalone operator
+		if n := len(terms); n > 0 && isOperator(terms[n-1]) {
+			if field == "-" || isOperator(field) {
+				return nil, fmt.Errorf("operator %q: missing version", terms[n-1])
+			}
+
+			terms[n-1] += field
+			continue
+		}
+
+		terms = append(terms, field)
+	}
+
+	for i := 0; i < len(terms); i++ {
+		term := terms[i]
+		if term == "-" {
+			return nil, errors.New("hyphen range: expected a version either side of \"-\"")
+		}
+
+		if i+1 < len(terms) && terms[i+1] == "-" {
+			if i+2 >= len(terms) || terms[i+2] == "-" {
+				return nil, errors.New("hyphen range: expected a version either side of \"-\"")
+			}
+
+			upper := terms[i+2]
+			if hasOperator(term) || hasOperator(upper) {
+				return nil, fmt.Errorf("hyphen range %q - %q: versions cannot have operators", term, upper)
+			}
+
+			lowerComparators, err := expand(">=", term)
+			if err != nil {
+				return nil, err
+			}
+
+			upperComparators, err := expand("<=", upper)
+			if err != nil {
+				return nil, err
+			}
+
+			comparators = append(comparators, lowerComparators...)
+			comparators = append(comparators, upperComparators...)
+			i += 2
+
+			continue
+		}
+
+		if isOperator(term) {
+			return nil, fmt.Errorf("operator %q: missing version", term)
+		}
+
+		op := term[:len(term)-len(strings.TrimLeft(term, operators))]
+		expanded, err := expand(op, term[len(op):])
+		if err != nil {
+			return nil, err
+		}
+
+		comparators = append(comparators, expanded...)
+	}
+
+	return comparators, nil
+}
+
+func isOperator(term string) bool {
+	return strings.Trim(term, operators) == ""
+}
+
+func hasOperator(term string) bool {
+	return strings.ContainsAny(term[:1], operators)
+}
+
+// expand converts an operator and possibly partial version into comparators on complete versions.
+func expand(op, version string) ([]comparator, error) {
+	if version == "" {
+		return nil, errors.New("missing version")
+	}
+
+	v, parts, err := parse(version)
+	if err != nil {
+		return nil, err
+	}
+
+	// next returns the first version after the range described by the first n parts of v
+	next := func(n int) *Version {
+		switch n {
+		case 0:
+			return nil
+		case 1:
+			return &Version{Major: v.Major + 1}
+		case 2:
+			return &Version{Major: v.Major, Minor: v.Minor + 1}
+		}
+
+		return &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+
+	lower := &Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch, Prerelease: v.Prerelease}
+
+	// between returns comparators for [lower, upper) where a nil upper is unbounded
+	between := func(upper *Version) []comparator {
+		comparators := []comparator{{op: ">=", version: lower}}
+		if upper != nil {
+			comparators = append(comparators, comparator{op: "<", version: upper})
+		}
+
+		return comparators
+	}
+
+	switch op {
+	case "", "=", "==":
+		if parts == 3 {
+			return []comparator{{op: "=", version: lower}}, nil
+		}
+
+		return between(next(parts)), nil
+	case "!=":
+		if parts == 0 {
+			return nil, fmt.Errorf("%s%s matches no version", op, version)
+		}
+
+		if parts == 3 {
+			return []comparator{{op: "!=", version: lower}}, nil
+		}
+
+		return []comparator{{op: "!=", version: lower, upper: next(parts)}}, nil
+	case ">":
+		if parts == 0 {
+			return nil, fmt.Errorf("%s%s matches no version", op, version)
+		}
+
+		if parts == 3 {
+			return []comparator{{op: ">", version: lower}}, nil
+		}
+
+		return []comparator{{op: ">=", version: next(parts)}}, nil
+	case ">=":
+		return []comparator{{op: ">=", version: lower}}, nil
+	case "<":
+		if parts == 0 {
+			return nil, fmt.Errorf("%s%s matches no version", op, version)
+		}
+
+		return []comparator{{op: "<", version: lower}}, nil
+	case "<=":
+		if parts == 3 {
+			return []comparator{{op: "<=", version: lower}}, nil
+		}
+
+		if parts == 0 {
+			return nil, nil
+		}
+
+		return []comparator{{op: "<", version: next(parts)}}, nil
+	case "~":
+		if parts == 3 {
+			return between(next(2)), nil
+		}
+
+		return between(next(parts)), nil
+	case "^":
+		switch {
+		case v.Major > 0 || parts <= 1:
+			return between(next(1)), nil
+		case v.Minor > 0 || parts == 2:
+			return between(next(2)), nil
+		}
+
+		return between(next(3)), nil
+	}
+
+	return nil, fmt.Errorf("unsupported operator %q", op)
+}
+
+// WithPrerelease returns a copy of the constraint which is also satisfied
+// by any pre-release version within its ranges.
+func (c *Constraint) WithPrerelease() *Constraint {
+	cp := *c
+	cp.prerelease = true
+	return &cp
+}
+
+// String returns the constraint as it was originally parsed.
+func (c *Constraint) String() string {
+	return c.original
+}
+
+// Check returns true if the version satisfies the constraint.
+func (c *Constraint) Check(v *Version) bool {
+	for _, group := range c.groups {
+		if checkGroup(group, v, c.prerelease) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkGroup(group []comparator, v *Version, prerelease bool) bool {
+	allowPrerelease := prerelease || len(v.Prerelease) == 0
+	for _, c := range group {
+		if !c.check(v) {
+			return false
+		}
+
+		if len(c.version.Prerelease) > 0 &&
+			c.version.Major == v.Major && c.version.Minor == v.Minor && c.version.Patch == v.Patch {
+			allowPrerelease = true
+		}
+	}
+
+	return allowPrerelease
+}
+
+// Latest returns the greatest of the provided versions which satisfies the constraint.
+// Versions which cannot be parsed are ignored. It returns false if no version matches.
+func (c *Constraint) Latest(versions []string) (string, bool) {
+	var candidates []*Version
+	for _, version := range versions {
+		v, err := Parse(version)
+		if err != nil {
+			continue
+		}
+
+		if c.Check(v) {
+			candidates = append(candidates, v)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	return slices.MaxFunc(candidates, (*Version).Compare).String(), true
+}
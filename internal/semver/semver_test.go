@@ -0,0 +1,361 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		version    string
+		expected   string
+		invalid    bool
+		prerelease []string
+	}{
+		{version: "1.2.3", expected: "1.2.3"},
+		{version: "v1.2.3", expected: "v1.2.3"},
+		{version: "1.2.3-beta.1+build.5", expected: "1.2.3-beta.1+build.5", prerelease: []string{"beta", "1"}},
+		{version: "1.2", invalid: true},
+		{version: "1.2.x", invalid: true},
+		{version: "1.2.3.4", invalid: true},
+		{version: "1.2.3-", invalid: true},
+		{version: "one.2.3", invalid: true},
+	} {
+		t.Run(test.version, func(t *testing.T) {
+			v, err := Parse(test.version)
+			if test.invalid {
+				if err == nil {
+					t.Fatalf("expected error, parsed %v", v)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v.String() != test.expected {
+				t.Errorf("expected %q, found %q", test.expected, v.String())
+			}
+
+			if len(v.Prerelease) != len(test.prerelease) {
+				t.Fatalf("expected pre-release %v, found %v", test.prerelease, v.Prerelease)
+			}
+
+			for i := range v.Prerelease {
+				if v.Prerelease[i] != test.prerelease[i] {
+					t.Errorf("expected pre-release %v, found %v", test.prerelease, v.Prerelease)
+				}
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// each version has lower precedence than the next
+	ordered := []string{
+		"0.9.9",
+		"1.0.0-0",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := 0; i+1 < len(ordered); i++ {
+		a, b := mustParse(t, ordered[i]), mustParse(t, ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+
+	if c := mustParse(t, "1.2.3+a").Compare(mustParse(t, "v1.2.3+b")); c != 0 {
+		t.Errorf("expected build metadata to be ignored, found %d", c)
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	for _, test := range []struct {
+		constraint string
+		prerelease bool
+		matches    []string
+		excludes   []string
+	}{
+		{
+			constraint: "",
+			matches:    []string{"0.0.0", "1.2.3", "10.0.0"},
+			excludes:   []string{"1.2.3-beta"},
+		},
+		{
+			constraint: "1.2.3",
+			matches:    []string{"1.2.3", "v1.2.3+build"},
+			excludes:   []string{"1.2.4", "1.2.3-beta"},
+		},
+		{
+			constraint: "=1.2",
+			matches:    []string{"1.2.0", "1.2.9"},
+			excludes:   []string{"1.1.9", "1.3.0"},
+		},
+		{
+			constraint: ">= 1.2, < 1.4",
+			matches:    []string{"1.2.0", "1.3.9"},
+			excludes:   []string{"1.1.9", "1.4.0"},
+		},
+		{
+			constraint: ">1.2",
+			matches:    []string{"1.3.0", "2.0.0"},
+			excludes:   []string{"1.2.0", "1.2.9"},
+		},
+		{
+			constraint: ">1.2.3",
+			matches:    []string{"1.2.4"},
+			excludes:   []string{"1.2.3"},
+		},
+		{
+			constraint: "<=1.2",
+			matches:    []string{"1.1.0", "1.2.9"},
+			excludes:   []string{"1.3.0"},
+		},
+		{
+			constraint: "<1.2",
+			matches:    []string{"1.1.9"},
+			excludes:   []string{"1.2.0"},
+		},
+		{
+			constraint: "<=*",
+			matches:    []string{"0.0.0", "3.0.0"},
+		},
+		{
+			constraint: "~1.2.3",
+			matches:    []string{"1.2.3", "1.2.9"},
+			excludes:   []string{"1.2.2", "1.3.0"},
+		},
+		{
+			constraint: "~1.2",
+			matches:    []string{"1.2.0", "1.2.9"},
+			excludes:   []string{"1.3.0"},
+		},
+		{
+			constraint: "~1",
+			matches:    []string{"1.0.0", "1.9.9"},
+			excludes:   []string{"0.9.9", "2.0.0"},
+		},
+		{
+			constraint: "^1.2.3",
+			matches:    []string{"1.2.3", "1.9.0"},
+			excludes:   []string{"1.2.2", "2.0.0"},
+		},
+		{
+			constraint: "^0.3.1",
+			matches:    []string{"0.3.1", "0.3.9"},
+			excludes:   []string{"0.3.0", "0.4.0"},
+		},
+		{
+			constraint: "^0.0.3",
+			matches:    []string{"0.0.3"},
+			excludes:   []string{"0.0.4"},
+		},
+		{
+			constraint: "^0.0",
+			matches:    []string{"0.0.0", "0.0.9"},
+			excludes:   []string{"0.1.0"},
+		},
+		{
+			constraint: "^0.x",
+			matches:    []string{"0.0.1", "0.9.0"},
+			excludes:   []string{"1.0.0"},
+		},
+		{
+			constraint: "1.2.x",
+			matches:    []string{"1.2.0", "1.2.9"},
+			excludes:   []string{"1.3.0"},
+		},
+		{
+			constraint: "1.X",
+			matches:    []string{"1.0.0", "1.9.0"},
+			excludes:   []string{"2.0.0"},
+		},
+		{
+			constraint: "*",
+			matches:    []string{"0.0.0", "9.9.9"},
+			excludes:   []string{"1.0.0-rc.1"},
+		},
+		{
+			constraint: "1.2 - 1.4",
+			matches:    []string{"1.2.0", "1.4.9"},
+			excludes:   []string{"1.1.9", "1.5.0"},
+		},
+		{
+			constraint: "1.2.3 - 2.3.4",
+			matches:    []string{"1.2.3", "2.3.4"},
+			excludes:   []string{"1.2.2", "2.3.5"},
+		},
+		{
+			constraint: "1.2.3 - 2",
+			matches:    []string{"2.9.9"},
+			excludes:   []string{"3.0.0"},
+		},
+		{
+			constraint: "^0.3.1 || 2.x",
+			matches:    []string{"0.3.5", "2.0.0", "2.9.0"},
+			excludes:   []string{"0.4.0", "1.0.0", "3.0.0"},
+		},
+		{
+			constraint: "<1.0.0 || >=2.0.0 <2.1.0 || 3.0.0",
+			matches:    []string{"0.1.0", "2.0.5", "3.0.0"},
+			excludes:   []string{"1.0.0", "2.1.0", "3.0.1"},
+		},
+		{
+			constraint: "!=1.2.3",
+			matches:    []string{"1.2.2", "1.2.4"},
+			excludes:   []string{"1.2.3"},
+		},
+		{
+			constraint: "!=1.2",
+			matches:    []string{"1.1.9", "1.3.0"},
+			excludes:   []string{"1.2.0", "1.2.9"},
+		},
+		{
+			constraint: ">=1.0.0 !=1.2 !=1.4",
+			matches:    []string{"1.1.0", "1.3.0", "1.5.0"},
+			excludes:   []string{"0.9.0", "1.2.5", "1.4.0"},
+		},
+		{
+			constraint: "!=1",
+			matches:    []string{"0.9.9", "2.0.0"},
+			excludes:   []string{"1.0.0", "1.9.9"},
+		},
+		{
+			// pre-releases only match comparators on a pre-release of the same version
+			constraint: ">=1.2.3-beta.2",
+			matches:    []string{"1.2.3-beta.2", "1.2.3-beta.10", "1.2.3-rc.1", "1.2.3", "1.3.0"},
+			excludes:   []string{"1.2.3-beta.1", "1.2.3-alpha", "1.3.0-beta.1"},
+		},
+		{
+			constraint: "~1.2.3-beta.2",
+			matches:    []string{"1.2.3-beta.4", "1.2.5"},
+			excludes:   []string{"1.2.4-beta.1", "1.3.0"},
+		},
+		{
+			constraint: "^1.2",
+			matches:    []string{"1.2.0"},
+			excludes:   []string{"1.3.0-rc.1", "2.0.0-rc.1"},
+		},
+		{
+			constraint: "^1.2",
+			prerelease: true,
+			matches:    []string{"1.2.0", "1.3.0-rc.1"},
+			excludes:   []string{"1.2.0-rc.1", "2.0.0"},
+		},
+	} {
+		name := test.constraint
+		if test.prerelease {
+			name += " with pre-releases"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			c, err := ParseConstraint(test.constraint)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.prerelease {
+				c = c.WithPrerelease()
+			}
+
+			for _, version := range test.matches {
+				if !c.Check(mustParse(t, version)) {
+					t.Errorf("expected %q to match %q", version, test.constraint)
+				}
+			}
+
+			for _, version := range test.excludes {
+				if c.Check(mustParse(t, version)) {
+					t.Errorf("expected %q not to match %q", version, test.constraint)
+				}
+			}
+		})
+	}
+}
+
+func TestParseConstraint_Invalid(t *testing.T) {
+	for _, constraint := range []string{
+		// wildcards which no version satisfies
+		">*",
+		"<*",
+		"!=*",
+		"<x",
+		// malformed hyphen ranges
+		"1.2 -",
+		"- 1.4",
+		"1.2 - - 1.4",
+		">=1.2 - 1.4",
+		"1.2 - <1.4",
+		// dangling operators
+		">=",
+		">= - 1.2",
+		">= <1.2",
+		// malformed versions
+		"1.2.3.4",
+		"1.x-beta",
+		"=>1.2",
+		"1.a",
+	} {
+		t.Run(constraint, func(t *testing.T) {
+			if c, err := ParseConstraint(constraint); err == nil {
+				t.Errorf("expected error, parsed %v", c.groups)
+			}
+		})
+	}
+}
+
+func TestLatest(t *testing.T) {
+	versions := []string{"latest", "1.2.0", "v1.4.1", "1.4.2-rc.1", "1.10.0", "2.0.0", "main"}
+
+	for _, test := range []struct {
+		constraint string
+		prerelease bool
+		expected   string
+		found      bool
+	}{
+		{constraint: "^1.2", expected: "1.10.0", found: true},
+		{constraint: "~1.4", expected: "v1.4.1", found: true},
+		{constraint: "~1.4", prerelease: true, expected: "1.4.2-rc.1", found: true},
+		{constraint: "", expected: "2.0.0", found: true},
+		{constraint: "3.x"},
+	} {
+		t.Run(test.constraint, func(t *testing.T) {
+			c, err := ParseConstraint(test.constraint)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.prerelease {
+				c = c.WithPrerelease()
+			}
+
+			latest, found := c.Latest(versions)
+			if latest != test.expected || found != test.found {
+				t.Errorf("expected (%q, %v), found (%q, %v)", test.expected, test.found, latest, found)
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, version string) *Version {
+	t.Helper()
+
+	v, err := Parse(version)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return v
+}
@@ -1,8 +1,14 @@
 package glu
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"iter"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/get-glu/glu/pkg/containers"
 	"github.com/get-glu/glu/pkg/core"
@@ -25,6 +31,8 @@ type ResourcePipeline[R Resource] struct {
 	meta  Metadata
 	newFn func() R
 	nodes map[string]entry[R]
+	// order tracks the names of phases in the order they were added
+	order []string
 }
 
 // NewPipeline constructs and configures a new instance of *ResourcePipeline[R]
@@ -57,19 +65,54 @@ func (p *ResourcePipeline[R]) Add(r core.ResourcePhase[R], opts ...containers.Op
 		return fmt.Errorf("phase %q: %w", r.Metadata().Name, core.ErrAlreadyExists)
 	}
 
+	if p.reaches(add.PromotedFrom, r.Metadata().Name) {
+		return fmt.Errorf("phase %q: %w", r.Metadata().Name, core.ErrCycle)
+	}
+
 	p.nodes[r.Metadata().Name] = entry[R]{r, add}
+	p.order = append(p.order, r.Metadata().Name)
 
 	return nil
 }
 
-// PromotedFrom returns the phase which c is configured to promote from (get dependent phase).
-func (p *ResourcePipeline[R]) PromotedFrom(c core.ResourcePhase[R]) (core.ResourcePhase[R], bool) {
+// reaches returns true if the phase identified by name is reachable by
+// walking upstream from any of the provided phases.
+func (p *ResourcePipeline[R]) reaches(from []core.ResourcePhase[R], name string) bool {
+	seen := map[string]struct{}{}
+
+	var walk func([]core.ResourcePhase[R]) bool
+	walk = func(phases []core.ResourcePhase[R]) bool {
+		for _, phase := range phases {
+			if phase.Metadata().Name == name {
+				return true
+			}
+
+			if _, ok := seen[phase.Metadata().Name]; ok {
+				continue
+			}
+
+			seen[phase.Metadata().Name] = struct{}{}
+
+			if entry, ok := p.nodes[phase.Metadata().Name]; ok && walk(entry.opts.PromotedFrom) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return walk(from)
+}
+
+// PromotedFrom returns the phases which c is configured to promote from (get dependent phases)
+// along with the strategy used to select between them.
+func (p *ResourcePipeline[R]) PromotedFrom(c core.ResourcePhase[R]) ([]core.ResourcePhase[R], core.MergeStrategy) {
 	entry, ok := p.nodes[c.Metadata().Name]
-	if !ok || entry.opts.PromotedFrom == nil {
-		return nil, false
+	if !ok {
+		return nil, core.MergeStrategy{}
 	}
 
-	return entry.opts.PromotedFrom, true
+	return entry.opts.PromotedFrom, entry.opts.Strategy
 }
 
 // PhaseByName returns the Phase (if it exists) with a matching name.
@@ -83,30 +126,157 @@ func (p *ResourcePipeline[R]) PhaseByName(name string) (Phase, error) {
 }
 
 // Phases lists all phases in the pipeline with optional predicates.
+// Phases are listed in topological order, such that each phase is listed after
+// the phases it promotes from.
 func (p *ResourcePipeline[R]) Phases(opts ...containers.Option[core.PhaseOptions]) iter.Seq[Phase] {
 	var options core.PhaseOptions
 	containers.ApplyAll(&options, opts...)
 
 	return iter.Seq[Phase](func(yield func(Phase) bool) {
-		for _, entry := range p.nodes {
-			if !options.Matches(entry.ResourcePhase) {
+		for _, phase := range p.sorted() {
+			if !options.Matches(phase) {
 				continue
 			}
 
-			if !yield(entry.ResourcePhase) {
+			if !yield(phase) {
 				break
 			}
 		}
 	})
 }
 
-// Dependencies returns a map of Phase to Phase.
-// This map contains mappings of Phases to their dependent promotion source Phase (if configured).
-func (p *ResourcePipeline[R]) Dependencies() map[Phase]Phase {
-	deps := map[Phase]Phase{}
+// sorted returns the phases of the pipeline in topological order.
+// Cycles are prevented when phases are added, however, should one exist
+// the phases are returned in the order they were added.
+func (p *ResourcePipeline[R]) sorted() []Phase {
+	phases := p.ordered()
+	sorted, err := core.TopologicalSort(phases, p.Dependencies())
+	if err != nil {
+		return phases
+	}
+
+	return sorted
+}
+
+// ordered returns the phases of the pipeline in the order they were added.
+func (p *ResourcePipeline[R]) ordered() []Phase {
+	phases := make([]Phase, 0, len(p.order))
+	for _, name := range p.order {
+		phases = append(phases, p.nodes[name].ResourcePhase)
+	}
+
+	return phases
+}
+
+// PromoteAll promotes each phase in the pipeline in topological order.
+// By default, it stops at the first phase which fails to promote.
+// This can be overridden with core.ContinueOnError, in which case all failures
+// are collected and returned once every phase has been attempted.
+// Promotions held or denied by gates are not considered failures.
+func (p *ResourcePipeline[R]) PromoteAll(ctx context.Context, opts ...containers.Option[core.PromoteAllOptions]) error {
+	var options core.PromoteAllOptions
+	containers.ApplyAll(&options, opts...)
+
+	var errs []error
+	for phase := range p.Phases(options.PhaseOptions...) {
+		slog.Debug("cascading promotion", "pipeline", p.meta.Name, "phase", phase.Metadata().Name)
+
+		if err := phase.Promote(ctx); err != nil {
+			if errors.Is(err, core.ErrPromotionDenied) {
+				slog.Warn("promotion denied", "pipeline", p.meta.Name, "phase", phase.Metadata().Name, "reason", err)
+				continue
+			}
+
+			if errors.Is(err, core.ErrPromotionHeld) {
+				slog.Info("promotion held", "pipeline", p.meta.Name, "phase", phase.Metadata().Name, "reason", err)
+				continue
+			}
+
+			if !options.ContinueOnError {
+				return err
+			}
+
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Dependencies returns a map of Phase to upstream Phases.
+// This map represents the edges of the pipeline graph, where each Phase maps to
+// the set of dependent promotion source Phases (empty if none are configured).
+func (p *ResourcePipeline[R]) Dependencies() map[Phase][]Phase {
+	deps := make(map[Phase][]Phase, len(p.nodes))
 	for _, entry := range p.nodes {
-		deps[entry.ResourcePhase] = entry.opts.PromotedFrom
+		upstream := make([]Phase, 0, len(entry.opts.PromotedFrom))
+		for _, from := range entry.opts.PromotedFrom {
+			upstream = append(upstream, from)
+		}
+
+		deps[entry.ResourcePhase] = upstream
 	}
 
 	return deps
 }
+
+// Validate checks the pipeline is correctly configured.
+// It ensures the pipeline and each phase is named, every phase promotes from phases
+// registered on this pipeline, the dependency graph is acyclic and that no two phases
+// share an identical set of labels (which would make them indistinguishable to triggers).
+// All problems found are returned together as a *core.ValidationError.
+func (p *ResourcePipeline[R]) Validate() error {
+	var errs []error
+	if p.meta.Name == "" {
+		errs = append(errs, errors.New("pipeline name is required"))
+	}
+
+	labelSets := map[string]string{}
+	for _, name := range p.order {
+		entry := p.nodes[name]
+		if name == "" {
+			errs = append(errs, errors.New("phase name is required"))
+		}
+
+		for _, from := range entry.opts.PromotedFrom {
+			registered, ok := p.nodes[from.Metadata().Name]
+			if !ok || core.Phase(registered.ResourcePhase) != core.Phase(from) {
+				errs = append(errs, fmt.Errorf("phase %q promotes from unregistered phase %q: %w",
+					name, from.Metadata().Name, core.ErrNotFound))
+			}
+		}
+
+		labels := entry.Metadata().Labels
+		if len(labels) == 0 {
+			continue
+		}
+
+		key := labelsKey(labels)
+		if existing, ok := labelSets[key]; ok {
+			errs = append(errs, fmt.Errorf("phases %q and %q have identical labels {%s}: %w",
+				existing, name, key, core.ErrAlreadyExists))
+			continue
+		}
+
+		labelSets[key] = name
+	}
+
+	if _, err := core.TopologicalSort(p.ordered(), p.Dependencies()); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return &core.ValidationError{Pipeline: p.meta.Name, Errs: errs}
+	}
+
+	return nil
+}
+
+func labelsKey(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,157 @@
+package approvals
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/get-glu/glu/pkg/core"
+)
+
+var _ core.Gate = (*ApprovalGate)(nil)
+
+// ErrAlreadyDecided is returned when an attempt is made to decide upon
+// an approval which is no longer pending.
+var ErrAlreadyDecided = errors.New("already decided")
+
+// Status is the state of an approval.
+type Status string
+
+const (
+	// StatusPending signifies an approval which is awaiting a decision
+	StatusPending = Status("pending")
+	// StatusApproved signifies an approval which allows the promotion to proceed
+	StatusApproved = Status("approved")
+	// StatusRejected signifies an approval which denies the promotion
+	StatusRejected = Status("rejected")
+)
+
+// Approval is a record of a promotion from one resource digest to another
+// for a particular pipeline phase which requires a human decision.
+type Approval struct {
+	ID         string      `json:"id"`
+	Pipeline   string      `json:"pipeline"`
+	Phase      string      `json:"phase"`
+	FromDigest string      `json:"from_digest"`
+	ToDigest   string      `json:"to_digest"`
+	Status     Status      `json:"status"`
+	CreatedAt  time.Time   `json:"created_at"`
+	DecidedAt  *time.Time  `json:"decided_at,omitempty"`
+	DecidedBy  *core.Actor `json:"decided_by,omitempty"`
+}
+
+// Store is a durable collection of approvals.
+// Get returns an error wrapping core.ErrNotFound when the approval does not exist.
+type Store interface {
+	Get(_ context.Context, id string) (*Approval, error)
+	Put(context.Context, *Approval) error
+	List(context.Context) ([]*Approval, error)
+}
+
+// ID returns the stable identifier for an approval of a promotion
+// between the provided digests within the pipeline phase.
+func ID(pipeline, phase, fromDigest, toDigest string) string {
+	sum := sha256.Sum256([]byte(pipeline + "/" + phase + "/" + fromDigest + "/" + toDigest))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// Approve marks the identified approval as approved.
+func Approve(ctx context.Context, store Store, id string) error {
+	return decide(ctx, store, id, StatusApproved)
+}
+
+// Reject marks the identified approval as rejected.
+func Reject(ctx context.Context, store Store, id string) error {
+	return decide(ctx, store, id, StatusRejected)
+}
+
+func decide(ctx context.Context, store Store, id string, status Status) error {
+	approval, err := store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if approval.Status != StatusPending {
+		return fmt.Errorf("approval %q is %s: %w", id, approval.Status, ErrAlreadyDecided)
+	}
+
+	now := time.Now().UTC()
+	approval.Status = status
+	approval.DecidedAt = &now
+	if actor, ok := core.ActorFromContext(ctx); ok {
+		approval.DecidedBy = &actor
+	}
+
+	return store.Put(ctx, approval)
+}
+
+// ApprovalGate is a core.Gate which holds promotions until they have been approved.
+type ApprovalGate struct {
+	store Store
+}
+
+// Gate returns a core.Gate which requires each promotion to be approved.
+// A pending approval is recorded in the store the first time a promotion is attempted.
+// Evaluating the gate otherwise (e.g. when inspecting or planning a phase) does not write to the store.
+// The promotion is held until the approval is approved and denied once it is rejected.
+func Gate(store Store) *ApprovalGate {
+	return &ApprovalGate{store: store}
+}
+
+func (g *ApprovalGate) Name() string {
+	return "approval"
+}
+
+func (g *ApprovalGate) Evaluate(ctx context.Context, req core.GateRequest) (core.GateResult, error) {
+	fromDigest, err := req.From.Digest()
+	if err != nil {
+		return core.GateResult{}, err
+	}
+
+	toDigest, err := req.To.Digest()
+	if err != nil {
+		return core.GateResult{}, err
+	}
+
+	id := ID(req.Pipeline.Name, req.Phase.Name, fromDigest, toDigest)
+
+	approval, err := g.store.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, core.ErrNotFound) {
+			return core.GateResult{}, err
+		}
+
+		if !req.Promoting {
+			return core.GateResult{
+				Decision: core.GateWait,
+				Reason:   fmt.Sprintf("approval %q will be requested when promoted", id),
+			}, nil
+		}
+
+		approval = &Approval{
+			ID:         id,
+			Pipeline:   req.Pipeline.Name,
+			Phase:      req.Phase.Name,
+			FromDigest: fromDigest,
+			ToDigest:   toDigest,
+			Status:     StatusPending,
+			CreatedAt:  time.Now().UTC(),
+		}
+
+		if err := g.store.Put(ctx, approval); err != nil {
+			return core.GateResult{}, err
+		}
+	}
+
+	switch approval.Status {
+	case StatusApproved:
+		return core.GateResult{Decision: core.GateAllow, Reason: fmt.Sprintf("approval %q approved", id)}, nil
+	case StatusRejected:
+		return core.GateResult{Decision: core.GateDeny, Reason: fmt.Sprintf("approval %q rejected", id)}, nil
+	default:
+		return core.GateResult{Decision: core.GateWait, Reason: fmt.Sprintf("approval %q pending", id)}, nil
+	}
+}
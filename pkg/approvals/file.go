@@ -0,0 +1,125 @@
+package approvals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/get-glu/glu/pkg/core"
+)
+
+var _ Store = (*FileStore)(nil)
+
+// FileStore is an implementation of Store which persists each approval
+// as a JSON file within a directory on the local filesystem.
+type FileStore struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewFileStore constructs a new *FileStore which stores approvals in dir.
+// The directory is created if it does not already exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating approvals directory: %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *FileStore) Get(_ context.Context, id string) (*Approval, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.read(id)
+}
+
+func (s *FileStore) read(id string) (*Approval, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return nil, fmt.Errorf("approval %q: %w", id, core.ErrNotFound)
+	}
+
+	data, err := os.ReadFile(s.path(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("approval %q: %w", id, core.ErrNotFound)
+		}
+
+		return nil, err
+	}
+
+	var approval Approval
+	if err := json.Unmarshal(data, &approval); err != nil {
+		return nil, fmt.Errorf("approval %q: %w", id, err)
+	}
+
+	return &approval, nil
+}
+
+// Put writes the approval to a temporary file before atomically
+// renaming it into place.
+func (s *FileStore) Put(_ context.Context, approval *Approval) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(approval, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fi, err := os.CreateTemp(s.dir, ".approval-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(fi.Name())
+
+	if _, err := fi.Write(data); err != nil {
+		_ = fi.Close()
+		return err
+	}
+
+	if err := fi.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(fi.Name(), s.path(approval.ID))
+}
+
+func (s *FileStore) List(context.Context) ([]*Approval, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals := make([]*Approval, 0, len(entries))
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok {
+			continue
+		}
+
+		approval, err := s.read(id)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, approval)
+	}
+
+	sortByCreated(approvals)
+
+	return approvals, nil
+}
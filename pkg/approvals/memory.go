@@ -0,0 +1,67 @@
+package approvals
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/get-glu/glu/pkg/core"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+// MemoryStore is an in-memory implementation of Store.
+// Approvals do not survive restarts of the process.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	approvals map[string]Approval
+}
+
+// NewMemoryStore constructs a new empty *MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{approvals: map[string]Approval{}}
+}
+
+func (s *MemoryStore) Get(_ context.Context, id string) (*Approval, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	approval, ok := s.approvals[id]
+	if !ok {
+		return nil, fmt.Errorf("approval %q: %w", id, core.ErrNotFound)
+	}
+
+	return &approval, nil
+}
+
+func (s *MemoryStore) Put(_ context.Context, approval *Approval) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.approvals[approval.ID] = *approval
+
+	return nil
+}
+
+func (s *MemoryStore) List(context.Context) ([]*Approval, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	approvals := make([]*Approval, 0, len(s.approvals))
+	for _, id := range slices.Sorted(maps.Keys(s.approvals)) {
+		approval := s.approvals[id]
+		approvals = append(approvals, &approval)
+	}
+
+	sortByCreated(approvals)
+
+	return approvals, nil
+}
+
+func sortByCreated(approvals []*Approval) {
+	slices.SortStableFunc(approvals, func(a, b *Approval) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+}
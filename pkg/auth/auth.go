@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrNoCredentials is returned by an Authenticator when the request does not
+	// carry credentials of the kind it understands.
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrUnauthenticated is returned when presented credentials are invalid.
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrForbidden is returned when an identity is not permitted to perform an action.
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Identity is an authenticated caller of the API.
+type Identity struct {
+	// Subject uniquely identifies the caller (e.g. a token name, JWT sub or certificate CN).
+	Subject string `json:"subject"`
+	// Name is an optional human readable name for the caller.
+	Name string `json:"name,omitempty"`
+	// Email is an optional email address for the caller.
+	Email string `json:"email,omitempty"`
+	// Method is the authentication method which established the identity.
+	Method string `json:"method"`
+	// Attributes are additional properties (e.g. team or groups) used during authorization.
+	Attributes map[string][]string `json:"attributes,omitempty"`
+}
+
+// HasAttribute returns true if the identity has the provided value for attribute key.
+func (i *Identity) HasAttribute(key, value string) bool {
+	return slices.Contains(i.Attributes[key], value)
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx which carries the provided identity.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFromContext returns the identity carried by ctx (if any).
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+// Authenticator establishes the identity of the caller of a request.
+// It returns an error wrapping ErrNoCredentials when the request does not contain
+// credentials it supports, so that the next authenticator can be attempted.
+type Authenticator interface {
+	Authenticate(*http.Request) (*Identity, error)
+}
+
+// Middleware returns HTTP middleware which authenticates each request using the
+// provided authenticators in order. The first authenticator to establish an identity
+// wins and the identity is added to the requests context.
+// Requests which cannot be authenticated are rejected with a 401.
+func Middleware(authenticators ...Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, authenticator := range authenticators {
+				id, err := authenticator.Authenticate(r)
+				if err != nil {
+					if errors.Is(err, ErrNoCredentials) {
+						continue
+					}
+
+					slog.Debug("authentication failed", "path", r.URL.Path, "error", err)
+
+					unauthorized(w)
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), id)))
+				return
+			}
+
+			unauthorized(w)
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	http.Error(w, ErrUnauthenticated.Error(), http.StatusUnauthorized)
+}
+
+// bearerToken returns the token from the requests Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
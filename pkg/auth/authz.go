@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/get-glu/glu/pkg/core"
+)
+
+// Action is an operation on a pipeline phase subject to authorization.
+type Action string
+
+const (
+	// ActionPromote is the promotion of a phase
+	ActionPromote = Action("promote")
+	// ActionRollback is the rollback of a phase to a previous revision
+	ActionRollback = Action("rollback")
+	// ActionPin is the pinning or unpinning of a phase
+	ActionPin = Action("pin")
+	// ActionApprove is the approval or rejection of a phase promotion
+	ActionApprove = Action("approve")
+)
+
+// Request describes an identity attempting to perform an action on a phase.
+type Request struct {
+	Identity *Identity
+	Action   Action
+	Pipeline core.Metadata
+	Phase    core.Metadata
+}
+
+// Authorizer decides whether or not a request is permitted.
+// It returns an error wrapping ErrForbidden when it is not.
+type Authorizer interface {
+	Authorize(context.Context, Request) error
+}
+
+// Rule restricts who may perform a set of actions on a set of phases.
+// The Actions, Pipelines, PipelineLabels and PhaseLabels fields select the requests
+// the rule applies to, where an empty field matches everything.
+// The Subjects and Attributes fields describe the identities permitted to
+// perform the selected requests, where an empty field permits any identity.
+type Rule struct {
+	Actions        []Action
+	Pipelines      []string
+	PipelineLabels map[string]string
+	PhaseLabels    map[string]string
+
+	Subjects   []string
+	Attributes map[string]string
+}
+
+// Rules is an Authorizer built from a set of rules.
+// A request is permitted when no rules apply to it, or when its identity
+// is permitted by at-least one of the rules which do apply.
+// For example, the following only permits identities with team=payments
+// to promote phases labelled env=production:
+//
+//	Rules{{
+//		Actions:     []Action{ActionPromote},
+//		PhaseLabels: map[string]string{"env": "production"},
+//		Attributes:  map[string]string{"team": "payments"},
+//	}}
+type Rules []Rule
+
+func (r Rules) Authorize(_ context.Context, req Request) error {
+	var applied bool
+	for _, rule := range r {
+		if !rule.selects(req) {
+			continue
+		}
+
+		if rule.permits(req.Identity) {
+			return nil
+		}
+
+		applied = true
+	}
+
+	if applied {
+		subject := "anonymous"
+		if req.Identity != nil {
+			subject = req.Identity.Subject
+		}
+
+		return fmt.Errorf("%q cannot %s %s/%s: %w", subject, req.Action, req.Pipeline.Name, req.Phase.Name, ErrForbidden)
+	}
+
+	return nil
+}
+
+func (r Rule) selects(req Request) bool {
+	if len(r.Actions) > 0 && !slices.Contains(r.Actions, req.Action) {
+		return false
+	}
+
+	if len(r.Pipelines) > 0 && !slices.Contains(r.Pipelines, req.Pipeline.Name) {
+		return false
+	}
+
+	return labelsMatch(r.PipelineLabels, req.Pipeline.Labels) &&
+		labelsMatch(r.PhaseLabels, req.Phase.Labels)
+}
+
+func (r Rule) permits(id *Identity) bool {
+	if id == nil {
+		return len(r.Subjects) == 0 && len(r.Attributes) == 0
+	}
+
+	if len(r.Subjects) > 0 && !slices.Contains(r.Subjects, id.Subject) {
+		return false
+	}
+
+	for k, v := range r.Attributes {
+		if !id.HasAttribute(k, v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func labelsMatch(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,252 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/get-glu/glu/pkg/containers"
+)
+
+// KeySet is a JSON Web Key Set loaded from a file or URL.
+// Keys are cached and refreshed periodically, or on demand
+// when a token references an unknown key ID.
+type KeySet struct {
+	load     func(context.Context) ([]byte, error)
+	interval time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	keys    map[string]crypto.PublicKey
+	fetched time.Time
+}
+
+// KeySetFromFile returns a KeySet which reads its keys from the provided path.
+func KeySetFromFile(path string, opts ...containers.Option[KeySet]) *KeySet {
+	return newKeySet(func(context.Context) ([]byte, error) {
+		return os.ReadFile(path)
+	}, opts...)
+}
+
+// KeySetFromURL returns a KeySet which fetches its keys from the provided URL.
+func KeySetFromURL(url string, opts ...containers.Option[KeySet]) *KeySet {
+	return newKeySet(func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetching jwks %q: unexpected status %s", url, resp.Status)
+		}
+
+		return io.ReadAll(resp.Body)
+	}, opts...)
+}
+
+// WithRefreshInterval overrides how often keys are reloaded (default 5m).
+func WithRefreshInterval(d time.Duration) containers.Option[KeySet] {
+	return func(k *KeySet) {
+		k.interval = d
+	}
+}
+
+func newKeySet(load func(context.Context) ([]byte, error), opts ...containers.Option[KeySet]) *KeySet {
+	k := &KeySet{
+		load:     load,
+		interval: 5 * time.Minute,
+		now:      time.Now,
+	}
+
+	containers.ApplyAll(k, opts...)
+
+	return k
+}
+
+// minRefresh bounds how often an unknown key ID can trigger a reload.
+const minRefresh = 30 * time.Second
+
+// Key returns the public key identified by kid.
+// When kid is empty, and the set contains a single key, then that key is returned.
+func (k *KeySet) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	since := k.now().Sub(k.fetched)
+	if k.keys == nil || since > k.interval {
+		if err := k.refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	key, ok := k.lookup(kid)
+	if !ok && since > minRefresh {
+		// the key may have been rotated since we last fetched
+		if err := k.refresh(ctx); err != nil {
+			return nil, err
+		}
+
+		key, ok = k.lookup(kid)
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("jwks: key %q not found: %w", kid, ErrUnauthenticated)
+	}
+
+	return key, nil
+}
+
+func (k *KeySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, true
+		}
+	}
+
+	key, ok := k.keys[kid]
+	return key, ok
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k *KeySet) refresh(ctx context.Context) error {
+	data, err := k.load(ctx)
+	if err != nil {
+		if k.keys != nil {
+			// continue serving the previously loaded keys
+			slog.Warn("refreshing jwks", "error", err)
+			return nil
+		}
+
+		return fmt.Errorf("loading jwks: %w", err)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("parsing jwks: %w", err)
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		pub, err := key.publicKey()
+		if err != nil {
+			slog.Warn("skipping jwk", "kid", key.Kid, "error", err)
+			continue
+		}
+
+		keys[key.Kid] = pub
+	}
+
+	k.keys, k.fetched = keys, k.now()
+
+	return nil
+}
+
+func (j jwk) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		pub := &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		// ensures the point is valid for the curve
+		if _, err := pub.ECDH(); err != nil {
+			return nil, err
+		}
+
+		return pub, nil
+	case "OKP":
+		if j.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", j.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 key length %d", len(x))
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", j.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
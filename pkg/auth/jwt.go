@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/get-glu/glu/pkg/containers"
+)
+
+var _ Authenticator = (*JWTAuthenticator)(nil)
+
+// JWTAuthenticator authenticates requests carrying a signed JWT bearer token
+// (e.g. an OIDC ID token). Signatures are verified against keys from a JWKS and
+// tokens must carry an exp claim.
+type JWTAuthenticator struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// JWT returns an authenticator which verifies bearer tokens using the provided key set.
+func JWT(keys *KeySet, opts ...containers.Option[JWTAuthenticator]) *JWTAuthenticator {
+	a := &JWTAuthenticator{
+		keys:   keys,
+		leeway: time.Minute,
+		now:    time.Now,
+	}
+
+	containers.ApplyAll(a, opts...)
+
+	return a
+}
+
+// WithIssuer requires that tokens carry the provided iss claim.
+func WithIssuer(iss string) containers.Option[JWTAuthenticator] {
+	return func(a *JWTAuthenticator) {
+		a.issuer = iss
+	}
+}
+
+// WithAudience requires that tokens carry the provided value in the aud claim.
+func WithAudience(aud string) containers.Option[JWTAuthenticator] {
+	return func(a *JWTAuthenticator) {
+		a.audience = aud
+	}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Authenticate verifies the requests bearer token and returns an identity
+// derived from its claims. The sub claim becomes the subject and all other
+// string (or string array) claims are exposed as attributes.
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		// not a JWT (e.g. an unrecognised static token)
+		return nil, ErrNoCredentials
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("jwt header: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("jwt signature: %w", err)
+	}
+
+	key, err := a.keys.Key(r.Context(), header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verify(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	claims := map[string]any{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("jwt claims: %w", err)
+	}
+
+	if err := a.validate(claims); err != nil {
+		return nil, err
+	}
+
+	id := &Identity{Method: "jwt", Attributes: map[string][]string{}}
+	for k, v := range claims {
+		switch v := v.(type) {
+		case string:
+			id.Attributes[k] = []string{v}
+		case []any:
+			for _, e := range v {
+				if s, ok := e.(string); ok {
+					id.Attributes[k] = append(id.Attributes[k], s)
+				}
+			}
+		}
+	}
+
+	id.Subject, _ = claims["sub"].(string)
+	id.Email, _ = claims["email"].(string)
+	id.Name, _ = claims["name"].(string)
+	if id.Name == "" {
+		id.Name, _ = claims["preferred_username"].(string)
+	}
+
+	if id.Subject == "" {
+		return nil, fmt.Errorf("jwt: missing sub claim: %w", ErrUnauthenticated)
+	}
+
+	return id, nil
+}
+
+func (a *JWTAuthenticator) validate(claims map[string]any) error {
+	now := a.now()
+
+	// tokens without an expiry would otherwise be valid forever
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("jwt: missing exp claim: %w", ErrUnauthenticated)
+	}
+
+	if now.After(time.Unix(int64(exp), 0).Add(a.leeway)) {
+		return fmt.Errorf("jwt: token expired: %w", ErrUnauthenticated)
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok {
+		if now.Add(a.leeway).Before(time.Unix(int64(nbf), 0)) {
+			return fmt.Errorf("jwt: token not yet valid: %w", ErrUnauthenticated)
+		}
+	}
+
+	if a.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != a.issuer {
+			return fmt.Errorf("jwt: unexpected issuer %q: %w", iss, ErrUnauthenticated)
+		}
+	}
+
+	if a.audience != "" {
+		var audiences []string
+		switch aud := claims["aud"].(type) {
+		case string:
+			audiences = []string{aud}
+		case []any:
+			for _, v := range aud {
+				if s, ok := v.(string); ok {
+					audiences = append(audiences, s)
+				}
+			}
+		}
+
+		if !slices.Contains(audiences, a.audience) {
+			return fmt.Errorf("jwt: audience %q not found: %w", a.audience, ErrUnauthenticated)
+		}
+	}
+
+	return nil
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// verify checks the signature over signed using the key and algorithm.
+// Only asymmetric algorithms are supported.
+func verify(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	case "EdDSA":
+	default:
+		return fmt.Errorf("jwt: unsupported algorithm %q: %w", alg, ErrUnauthenticated)
+	}
+
+	var digest []byte
+	if hash != 0 {
+		h := hash.New()
+		h.Write(signed)
+		digest = h.Sum(nil)
+	}
+
+	var err error
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		switch alg[:2] {
+		case "RS":
+			err = rsa.VerifyPKCS1v15(key, hash, digest, signature)
+		case "PS":
+			err = rsa.VerifyPSS(key, hash, digest, signature, nil)
+		default:
+			err = errors.New("algorithm does not match key type")
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if alg[:2] != "ES" || len(signature) != 2*size {
+			err = errors.New("invalid signature")
+			break
+		}
+
+		r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			err = errors.New("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if alg != "EdDSA" || !ed25519.Verify(key, signed, signature) {
+			err = errors.New("invalid signature")
+		}
+	default:
+		err = fmt.Errorf("unsupported key type %T", key)
+	}
+
+	if err != nil {
+		return fmt.Errorf("jwt: %w: %w", err, ErrUnauthenticated)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"net/http"
+)
+
+var _ Authenticator = (*ClientCertificateAuthenticator)(nil)
+
+// ClientCertificateAuthenticator authenticates requests using verified TLS client certificates.
+// The server must be configured to verify client certificates against a trusted CA pool.
+type ClientCertificateAuthenticator struct{}
+
+// ClientCertificates returns an authenticator which establishes identity
+// from the leaf of the verified client certificate chain.
+// The subject is the certificates common name and the organization and
+// organizational units are exposed as attributes.
+func ClientCertificates() *ClientCertificateAuthenticator {
+	return &ClientCertificateAuthenticator{}
+}
+
+func (a *ClientCertificateAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, ErrNoCredentials
+	}
+
+	leaf := r.TLS.VerifiedChains[0][0]
+
+	id := &Identity{
+		Subject: leaf.Subject.CommonName,
+		Name:    leaf.Subject.CommonName,
+		Method:  "mtls",
+		Attributes: map[string][]string{
+			"organization":        leaf.Subject.Organization,
+			"organizational_unit": leaf.Subject.OrganizationalUnit,
+		},
+	}
+
+	if len(leaf.EmailAddresses) > 0 {
+		id.Email = leaf.EmailAddresses[0]
+	}
+
+	return id, nil
+}
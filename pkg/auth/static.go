@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+)
+
+var _ Authenticator = (*StaticTokenAuthenticator)(nil)
+
+// StaticTokenAuthenticator authenticates requests carrying one of a fixed set of bearer tokens.
+type StaticTokenAuthenticator struct {
+	// tokens are keyed by their sha256 sum so that lookup does not
+	// leak the token contents through timing
+	tokens map[[sha256.Size]byte]*Identity
+}
+
+// StaticTokens returns an authenticator which maps each bearer token
+// to the provided identity.
+func StaticTokens(tokens map[string]*Identity) *StaticTokenAuthenticator {
+	a := &StaticTokenAuthenticator{tokens: map[[sha256.Size]byte]*Identity{}}
+	for token, id := range tokens {
+		id.Method = "token"
+		a.tokens[sha256.Sum256([]byte(token))] = id
+	}
+
+	return a
+}
+
+// Authenticate returns the identity associated with the requests bearer token.
+// Unrecognised tokens are treated as absent so that other bearer
+// authenticators (e.g. JWT) may be attempted.
+func (a *StaticTokenAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	id, ok := a.tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, fmt.Errorf("unknown token: %w", ErrNoCredentials)
+	}
+
+	return id, nil
+}
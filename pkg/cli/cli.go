@@ -8,25 +8,46 @@ import (
 	"iter"
 	"log/slog"
 	"os"
+	"os/user"
+	"slices"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/get-glu/glu/pkg/approvals"
+	"github.com/get-glu/glu/pkg/containers"
 	"github.com/get-glu/glu/pkg/core"
+	"github.com/go-git/go-git/v5/config"
 )
 
 type System interface {
 	GetPipeline(name string) (core.Pipeline, error)
 	Pipelines() iter.Seq2[string, core.Pipeline]
+	Approvals() (approvals.Store, error)
 }
 
 func Run(ctx context.Context, s System, args ...string) error {
+	ctx = core.WithActor(ctx, localActor())
+
 	switch args[1] {
 	case "inspect":
 		return inspect(ctx, s, args[2:]...)
 	case "promote":
 		return promote(ctx, s, args[2:]...)
+	case "history":
+		return history(ctx, s, args[2:]...)
+	case "rollback":
+		return rollback(ctx, s, args[2:]...)
+	case "pin":
+		return pin(ctx, s, args[2:]...)
+	case "unpin":
+		return unpin(ctx, s, args[2:]...)
+	case "approvals":
+		return approvalsCmd(ctx, s, args[2:]...)
+	case "proposals":
+		return proposalsCmd(ctx, s, args[2:]...)
 	default:
-		return fmt.Errorf("unexpected command %q (expected one of [inspect promote])", args[1])
+		return fmt.Errorf("unexpected command %q (expected one of [inspect promote history rollback pin unpin approvals proposals])", args[1])
 	}
 }
 
@@ -55,12 +76,12 @@ func inspect(ctx context.Context, s System, args ...string) (err error) {
 		fmt.Fprintln(wr, "NAME\tDEPENDS_ON")
 		deps := pipeline.Dependencies()
 		for phase := range pipeline.Phases() {
-			dependsName := ""
-			if depends, ok := deps[phase]; ok && depends != nil {
-				dependsName = depends.Metadata().Name
+			var dependsNames []string
+			for _, depends := range deps[phase] {
+				dependsNames = append(dependsNames, depends.Metadata().Name)
 			}
 
-			fmt.Fprintf(wr, "%s\t%s\n", phase.Metadata().Name, dependsName)
+			fmt.Fprintf(wr, "%s\t%s\n", phase.Metadata().Name, strings.Join(dependsNames, ","))
 		}
 		return nil
 	}
@@ -81,22 +102,86 @@ func inspect(ctx context.Context, s System, args ...string) (err error) {
 		extraFields = fields.PrinterFields()
 	}
 
+	var pinned string
+	if pinnable, ok := phase.(core.PinnablePhase); ok {
+		pin, err := pinnable.Pinned(ctx)
+		if err != nil && !errors.Is(err, core.ErrNotSupported) {
+			return err
+		}
+
+		if pin != nil {
+			pinned = pin.Digest
+		}
+	}
+
+	var gates []string
+	if gated, ok := phase.(core.GatedPhase); ok {
+		results, err := gated.EvaluateGates(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			gates = append(gates, fmt.Sprintf("%s=%s", result.Gate, result.Decision))
+		}
+	}
+
+	var proposals []string
+	if proposer, ok := phase.(core.ProposalPhase); ok {
+		open, err := proposer.Proposals(ctx)
+		if err != nil && !errors.Is(err, core.ErrNotSupported) {
+			return err
+		}
+
+		for _, proposal := range open {
+			proposals = append(proposals, describeProposal(proposal))
+		}
+	}
+
 	fmt.Fprint(wr, "NAME")
 	for _, field := range extraFields {
 		fmt.Fprintf(wr, "\t%s", field[0])
 	}
-	fmt.Fprintln(wr)
+	fmt.Fprintln(wr, "\tPINNED\tGATES\tPROPOSALS")
 
 	meta := phase.Metadata()
 	fmt.Fprintf(wr, "%s", meta.Name)
 	for _, field := range extraFields {
 		fmt.Fprintf(wr, "\t%s", field[1])
 	}
-	fmt.Fprintln(wr)
+	fmt.Fprintf(wr, "\t%s\t%s\t%s\n", pinned, strings.Join(gates, ","), strings.Join(proposals, ","))
 
 	return nil
 }
 
+// describeProposal summarises a proposal as its number (or branch) followed
+// by any known status (e.g. "#123(checks=failure,conflicted)").
+func describeProposal(proposal core.Proposal) string {
+	name := proposal.Branch
+	if proposal.Number > 0 {
+		name = fmt.Sprintf("#%d", proposal.Number)
+	}
+
+	var status []string
+	if proposal.Checks != "" {
+		status = append(status, "checks="+proposal.Checks)
+	}
+
+	if proposal.Approvals > 0 {
+		status = append(status, fmt.Sprintf("approvals=%d", proposal.Approvals))
+	}
+
+	if proposal.Mergeable != nil && !*proposal.Mergeable {
+		status = append(status, "conflicted")
+	}
+
+	if len(status) == 0 {
+		return name
+	}
+
+	return fmt.Sprintf("%s(%s)", name, strings.Join(status, ","))
+}
+
 type fields interface {
 	PrinterFields() [][2]string
 }
@@ -120,15 +205,21 @@ func (l labels) Set(v string) error {
 
 func promote(ctx context.Context, s System, args ...string) error {
 	var (
-		labels = labels{}
-		all    bool
-		apply  bool
+		labels          = labels{}
+		all             bool
+		apply           bool
+		cascade         bool
+		continueOnError bool
+		diff            bool
 	)
 
 	set := flag.NewFlagSet("promote", flag.ExitOnError)
 	set.Var(&labels, "label", "selector for filtering phases (format key=value)")
 	set.BoolVar(&apply, "apply", false, "actually run promotions (default dry-run)")
 	set.BoolVar(&all, "all", false, "promote all phases (ignores label filters)")
+	set.BoolVar(&cascade, "cascade", false, "promote entire pipelines end to end in dependency order")
+	set.BoolVar(&continueOnError, "continue-on-error", false, "continue cascading promotions after a phase fails (requires --cascade)")
+	set.BoolVar(&diff, "diff", false, "print the changes each promotion would make without applying them")
 	if err := set.Parse(args); err != nil {
 		return err
 	}
@@ -149,12 +240,24 @@ func promote(ctx context.Context, s System, args ...string) error {
 
 	slog.Info("starting promotion", logArgs...)
 
+	if diff {
+		return planPhases(ctx, s, set, labels, all)
+	}
+
 	if set.NArg() == 0 {
 		if len(labels) == 0 && !all {
 			return errors.New("please pass --all if you want to promote all phases")
 		}
 
 		for _, pipeline := range s.Pipelines() {
+			if cascade {
+				if err := cascadePipeline(ctx, pipeline, labels, apply, continueOnError); err != nil {
+					return err
+				}
+
+				continue
+			}
+
 			if err := promoteAllPhases(ctx, pipeline.Phases(core.HasAllLabels(labels)), apply); err != nil {
 				return err
 			}
@@ -170,6 +273,10 @@ func promote(ctx context.Context, s System, args ...string) error {
 
 	phases := pipeline.Phases(core.HasAllLabels(labels))
 	if set.NArg() < 2 {
+		if cascade {
+			return cascadePipeline(ctx, pipeline, labels, apply, continueOnError)
+		}
+
 		return promoteAllPhases(ctx, phases, apply)
 	}
 
@@ -185,6 +292,153 @@ func promote(ctx context.Context, s System, args ...string) error {
 	return nil
 }
 
+func planPhases(ctx context.Context, s System, set *flag.FlagSet, labels labels, all bool) error {
+	var phases []core.Phase
+	switch set.NArg() {
+	case 0:
+		if len(labels) == 0 && !all {
+			return errors.New("please pass --all if you want to plan all phases")
+		}
+
+		for _, pipeline := range s.Pipelines() {
+			phases = slices.AppendSeq(phases, pipeline.Phases(core.HasAllLabels(labels)))
+		}
+	default:
+		pipeline, err := s.GetPipeline(set.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		if set.NArg() < 2 {
+			phases = slices.Collect(pipeline.Phases(core.HasAllLabels(labels)))
+			break
+		}
+
+		phase, err := pipeline.PhaseByName(set.Arg(1))
+		if err != nil {
+			return err
+		}
+
+		phases = append(phases, phase)
+	}
+
+	for _, phase := range phases {
+		plannable, ok := phase.(core.PlannablePhase)
+		if !ok {
+			continue
+		}
+
+		plan, err := plannable.Plan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if plan == nil {
+			fmt.Printf("# phase %q is up to date\n", phase.Metadata().Name)
+			continue
+		}
+
+		fmt.Printf("# phase %q: %s -> %s\n", phase.Metadata().Name, plan.FromDigest, plan.ToDigest)
+		for _, result := range plan.Gates {
+			fmt.Printf("# gate %q: %s (%s)\n", result.Gate, result.Decision, result.Reason)
+		}
+
+		fmt.Print(plan.Diff)
+	}
+
+	return nil
+}
+
+func cascadePipeline(ctx context.Context, pipeline core.Pipeline, labels labels, apply, continueOnError bool) error {
+	slog.Info("cascading promotion", "pipeline", pipeline.Metadata().Name, "dry-run", !apply)
+
+	if !apply {
+		return planCascade(ctx, pipeline, labels)
+	}
+
+	opts := []containers.Option[core.PromoteAllOptions]{
+		core.PromoteMatching(core.HasAllLabels(labels)),
+	}
+
+	if continueOnError {
+		opts = append(opts, core.ContinueOnError)
+	}
+
+	return pipeline.PromoteAll(ctx, opts...)
+}
+
+// planCascade logs the digest each phase would move from and to if the pipeline were cascaded.
+// Phases downstream of a pending promotion are planned against the digest their upstream
+// would be promoted to, as opposed to its current digest.
+func planCascade(ctx context.Context, pipeline core.Pipeline, labels labels) error {
+	var (
+		dependencies = pipeline.Dependencies()
+		pending      = map[core.Phase]string{}
+	)
+
+	for phase := range pipeline.Phases(core.HasAllLabels(labels)) {
+		name := phase.Metadata().Name
+
+		plannable, ok := phase.(core.PlannablePhase)
+		if !ok {
+			slog.Info("promoting phase", "phase", name, "dry-run", true)
+			continue
+		}
+
+		plan, err := plannable.Plan(ctx)
+		if err != nil {
+			return err
+		}
+
+		var from, to string
+		if plan != nil {
+			from, to = plan.FromDigest, plan.ToDigest
+		}
+
+		for _, upstream := range dependencies[phase] {
+			digest, ok := pending[upstream]
+			if !ok {
+				continue
+			}
+
+			to = digest
+			if from == "" {
+				if from, err = currentDigest(ctx, phase); err != nil {
+					return err
+				}
+			}
+
+			break
+		}
+
+		if to == "" || to == from {
+			slog.Info("phase up to date", "phase", name, "dry-run", true)
+			continue
+		}
+
+		pending[phase] = to
+
+		slog.Info("promoting phase", "phase", name, "from", from, "to", to, "dry-run", true)
+	}
+
+	return nil
+}
+
+// currentDigest returns the digest of the phases current resource.
+func currentDigest(ctx context.Context, phase core.Phase) (string, error) {
+	v, err := phase.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	resource, ok := v.(core.Resource)
+	if !ok {
+		return "", fmt.Errorf("phase %q: unexpected resource type %T", phase.Metadata().Name, v)
+	}
+
+	return resource.Digest()
+}
+
 func toIter[V any](v ...V) iter.Seq[V] {
 	return func(yield func(V) bool) {
 		for _, vv := range v {
@@ -201,10 +455,351 @@ func promoteAllPhases(ctx context.Context, phases iter.Seq[core.Phase], apply bo
 
 		if apply {
 			if err := phase.Promote(ctx); err != nil {
+				var gateErr *core.GateError
+				if !errors.As(err, &gateErr) {
+					return err
+				}
+
+				msg := "promotion held"
+				if gateErr.Decision() == core.GateDeny {
+					msg = "promotion denied"
+				}
+
+				for _, result := range gateErr.Results {
+					slog.Info(msg,
+						"phase", phase.Metadata().Name,
+						"gate", result.Gate,
+						"decision", result.Decision,
+						"reason", result.Reason)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func history(ctx context.Context, s System, args ...string) (err error) {
+	if len(args) < 2 {
+		return errors.New("usage: history <pipeline> <phase>")
+	}
+
+	phase, err := historicalPhase(s, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	states, err := phase.History(ctx)
+	if err != nil {
+		return err
+	}
+
+	wr := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	defer func() {
+		if ferr := wr.Flush(); ferr != nil && err == nil {
+			err = ferr
+		}
+	}()
+
+	fmt.Fprintln(wr, "REVISION\tDIGEST\tTIMESTAMP\tMESSAGE")
+	for _, state := range states {
+		message, _, _ := strings.Cut(state.Message, "\n")
+		fmt.Fprintf(wr, "%s\t%s\t%s\t%s\n", state.Revision, state.Digest, state.Timestamp.Format(time.RFC3339), message)
+	}
+
+	return nil
+}
+
+func rollback(ctx context.Context, s System, args ...string) error {
+	var apply bool
+
+	set := flag.NewFlagSet("rollback", flag.ExitOnError)
+	set.BoolVar(&apply, "apply", false, "actually run rollback (default dry-run)")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+
+	if set.NArg() < 3 {
+		return errors.New("usage: rollback [--apply] <pipeline> <phase> <revision>")
+	}
+
+	phase, err := historicalPhase(s, set.Arg(0), set.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	slog.Info("rolling back phase", "phase", phase.Metadata().Name, "revision", set.Arg(2), "dry-run", !apply)
+
+	if !apply {
+		return nil
+	}
+
+	return phase.Rollback(ctx, set.Arg(2))
+}
+
+func historicalPhase(s System, pipelineName, phaseName string) (core.HistoricalPhase, error) {
+	pipeline, err := s.GetPipeline(pipelineName)
+	if err != nil {
+		return nil, err
+	}
+
+	phase, err := pipeline.PhaseByName(phaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	historical, ok := phase.(core.HistoricalPhase)
+	if !ok {
+		return nil, fmt.Errorf("phase %q history: %w", phaseName, core.ErrNotSupported)
+	}
+
+	return historical, nil
+}
+
+func pin(ctx context.Context, s System, args ...string) error {
+	var apply bool
+
+	set := flag.NewFlagSet("pin", flag.ExitOnError)
+	set.BoolVar(&apply, "apply", false, "actually pin the phase (default dry-run)")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+
+	if set.NArg() < 2 {
+		return errors.New("usage: pin [--apply] <pipeline> <phase> [revision]")
+	}
+
+	phase, err := pinnablePhase(s, set.Arg(0), set.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	revision := set.Arg(2)
+
+	slog.Info("pinning phase", "phase", phase.Metadata().Name, "revision", revision, "dry-run", !apply)
+
+	if !apply {
+		return nil
+	}
+
+	return phase.Pin(ctx, revision)
+}
+
+func unpin(ctx context.Context, s System, args ...string) error {
+	var apply bool
+
+	set := flag.NewFlagSet("unpin", flag.ExitOnError)
+	set.BoolVar(&apply, "apply", false, "actually unpin the phase (default dry-run)")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+
+	if set.NArg() < 2 {
+		return errors.New("usage: unpin [--apply] <pipeline> <phase>")
+	}
+
+	phase, err := pinnablePhase(s, set.Arg(0), set.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	slog.Info("unpinning phase", "phase", phase.Metadata().Name, "dry-run", !apply)
+
+	if !apply {
+		return nil
+	}
+
+	return phase.Unpin(ctx)
+}
+
+func pinnablePhase(s System, pipelineName, phaseName string) (core.PinnablePhase, error) {
+	pipeline, err := s.GetPipeline(pipelineName)
+	if err != nil {
+		return nil, err
+	}
+
+	phase, err := pipeline.PhaseByName(phaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	pinnable, ok := phase.(core.PinnablePhase)
+	if !ok {
+		return nil, fmt.Errorf("phase %q pin: %w", phaseName, core.ErrNotSupported)
+	}
+
+	return pinnable, nil
+}
+
+func approvalsCmd(ctx context.Context, s System, args ...string) (err error) {
+	if len(args) == 0 {
+		return errors.New("usage: approvals list|approve|reject [id]")
+	}
+
+	store, err := s.Approvals()
+	if err != nil {
+		return err
+	}
+
+	// an in-memory store only lives as long as this process,
+	// so it cannot observe or decide upon the servers approvals
+	if _, ok := store.(*approvals.MemoryStore); ok {
+		return errors.New("approvals: no persistent store is configured (set approvals.path to a location shared with the server)")
+	}
+
+	switch args[0] {
+	case "list":
+		list, err := store.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		wr := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		defer func() {
+			if ferr := wr.Flush(); ferr != nil && err == nil {
+				err = ferr
+			}
+		}()
+
+		fmt.Fprintln(wr, "ID\tPIPELINE\tPHASE\tFROM\tTO\tSTATUS\tCREATED")
+		for _, approval := range list {
+			fmt.Fprintf(wr, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				approval.ID,
+				approval.Pipeline,
+				approval.Phase,
+				approval.FromDigest,
+				approval.ToDigest,
+				approval.Status,
+				approval.CreatedAt.Format(time.RFC3339))
+		}
+
+		return nil
+	case "approve", "reject":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: approvals %s <id>", args[0])
+		}
+
+		decide := approvals.Approve
+		if args[0] == "reject" {
+			decide = approvals.Reject
+		}
+
+		if err := decide(ctx, store, args[1]); err != nil {
+			return err
+		}
+
+		slog.Info("approval decided", "id", args[1], "decision", args[0])
+
+		return nil
+	default:
+		return fmt.Errorf("unexpected approvals command %q (expected one of [list approve reject])", args[0])
+	}
+}
+
+func proposalsCmd(ctx context.Context, s System, args ...string) error {
+	if len(args) == 0 {
+		return errors.New("usage: proposals prune [--apply] [--label key=value] [pipeline] [phase]")
+	}
+
+	switch args[0] {
+	case "prune":
+		return prune(ctx, s, args[1:]...)
+	default:
+		return fmt.Errorf("unexpected proposals command %q (expected one of [prune])", args[0])
+	}
+}
+
+func prune(ctx context.Context, s System, args ...string) (err error) {
+	var (
+		apply  bool
+		labels = labels{}
+	)
+
+	set := flag.NewFlagSet("prune", flag.ExitOnError)
+	set.BoolVar(&apply, "apply", false, "actually close the proposals (default dry-run)")
+	set.Var(&labels, "label", "selector for filtering phases (format key=value)")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+
+	slog.Info("pruning proposals", "dry-run", !apply)
+
+	var pipelines []core.Pipeline
+	if set.NArg() == 0 {
+		for _, pipeline := range s.Pipelines() {
+			pipelines = append(pipelines, pipeline)
+		}
+	} else {
+		pipeline, err := s.GetPipeline(set.Arg(0))
+		if err != nil {
+			return err
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	wr := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	defer func() {
+		if ferr := wr.Flush(); ferr != nil && err == nil {
+			err = ferr
+		}
+	}()
+
+	fmt.Fprintln(wr, "PIPELINE\tPHASE\tBRANCH")
+	for _, pipeline := range pipelines {
+		phases := pipeline.Phases(core.HasAllLabels(labels))
+		if set.NArg() > 1 {
+			phase, err := pipeline.PhaseByName(set.Arg(1))
+			if err != nil {
+				return err
+			}
+
+			phases = toIter(phase)
+		}
+
+		for phase := range phases {
+			prunable, ok := phase.(core.PrunablePhase)
+			if !ok {
+				continue
+			}
+
+			// only list the proposals which would be closed unless applying
+			run := prunable.Prunable
+			if apply {
+				run = prunable.Prune
+			}
+
+			branches, err := run(ctx)
+			if err != nil {
+				if errors.Is(err, core.ErrNotSupported) {
+					continue
+				}
+
 				return err
 			}
+
+			for _, branch := range branches {
+				fmt.Fprintf(wr, "%s\t%s\t%s\n", pipeline.Metadata().Name, phase.Metadata().Name, branch)
+			}
 		}
 	}
 
 	return nil
 }
+
+// localActor returns the actor for the invoking user derived from their
+// global git configuration, falling back to the current OS user.
+func localActor() core.Actor {
+	actor := core.Actor{Trigger: "cli"}
+	if conf, err := config.LoadConfig(config.GlobalScope); err == nil {
+		actor.Name, actor.Email = conf.User.Name, conf.User.Email
+	}
+
+	if actor.Name == "" {
+		if u, err := user.Current(); err == nil {
+			actor.Name = u.Username
+		}
+	}
+
+	return actor
+}
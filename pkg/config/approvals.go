@@ -0,0 +1,7 @@
+package config
+
+// Approvals configures where promotion approvals are stored.
+// When path is empty, approvals are only kept in-memory.
+type Approvals struct {
+	Path string `glu:"path"`
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Auth configures authentication and authorization for the HTTP API.
+// When no authentication methods are configured, the API is left unauthenticated.
+// Client certificate authentication is enabled by configuring server.tls.client_ca_file.
+type Auth struct {
+	Tokens []*StaticToken `glu:"tokens"`
+	JWT    *JWT           `glu:"jwt"`
+	Rules  []*AuthRule    `glu:"rules"`
+}
+
+// StaticToken is a bearer token which authenticates as the configured subject.
+type StaticToken struct {
+	Subject    string            `glu:"subject"`
+	Token      string            `glu:"token"`
+	Attributes map[string]string `glu:"attributes"`
+}
+
+// JWT configures validation of JWT bearer tokens (e.g. OIDC ID tokens)
+// against a JSON Web Key Set read from either a file or URL.
+type JWT struct {
+	Issuer          string        `glu:"issuer"`
+	Audience        string        `glu:"audience"`
+	JWKSFile        string        `glu:"jwks_file"`
+	JWKSURL         string        `glu:"jwks_url"`
+	RefreshInterval time.Duration `glu:"refresh_interval"`
+}
+
+// AuthRule restricts which identities may perform actions on selected phases.
+// See auth.Rule for details on how rules are evaluated.
+type AuthRule struct {
+	Actions        []string          `glu:"actions"`
+	Pipelines      []string          `glu:"pipelines"`
+	PipelineLabels map[string]string `glu:"pipeline_labels"`
+	PhaseLabels    map[string]string `glu:"phase_labels"`
+	Subjects       []string          `glu:"subjects"`
+	Attributes     map[string]string `glu:"attributes"`
+}
+
+func (a *Auth) setDefaults() error {
+	if a.JWT != nil && a.JWT.RefreshInterval == 0 {
+		a.JWT.RefreshInterval = 5 * time.Minute
+	}
+
+	return nil
+}
+
+func (a *Auth) validate() error {
+	for i, token := range a.Tokens {
+		if token.Subject == "" || token.Token == "" {
+			return fmt.Errorf("auth: tokens[%d]: fields subject and token are required", i)
+		}
+	}
+
+	if jwt := a.JWT; jwt != nil {
+		if (jwt.JWKSFile == "") == (jwt.JWKSURL == "") {
+			return errors.New("auth: jwt: exactly one of jwks_file or jwks_url is required")
+		}
+	}
+
+	for i, rule := range a.Rules {
+		for _, action := range rule.Actions {
+			switch action {
+			case "promote", "rollback", "pin", "approve":
+			default:
+				return fmt.Errorf("auth: rules[%d]: unexpected action %q", i, action)
+			}
+		}
+	}
+
+	return nil
+}
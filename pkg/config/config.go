@@ -14,10 +14,15 @@ import (
 
 type Config struct {
 	Log         Log         `glu:"log"`
+	Server      Server      `glu:"server"`
+	Auth        Auth        `glu:"auth"`
 	Credentials Credentials `glu:"credentials"`
+	Approvals   Approvals   `glu:"approvals"`
 	Sources     struct {
-		Git GitRepositories `glu:"git"`
-		OCI OCIRepositories `glu:"oci"`
+		Git  GitRepositories  `glu:"git"`
+		OCI  OCIRepositories  `glu:"oci"`
+		Helm HelmRepositories `glu:"helm"`
+		HTTP HTTPEndpoints    `glu:"http"`
 	} `glu:"sources"`
 }
 
@@ -26,6 +31,14 @@ func (c *Config) setDefaults() error {
 		return err
 	}
 
+	if err := c.Server.setDefaults(); err != nil {
+		return err
+	}
+
+	if err := c.Auth.setDefaults(); err != nil {
+		return err
+	}
+
 	if err := c.Sources.Git.setDefaults(); err != nil {
 		return err
 	}
@@ -34,6 +47,14 @@ func (c *Config) setDefaults() error {
 		return err
 	}
 
+	if err := c.Sources.Helm.setDefaults(); err != nil {
+		return err
+	}
+
+	if err := c.Sources.HTTP.setDefaults(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -42,6 +63,14 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.Server.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Auth.validate(); err != nil {
+		return err
+	}
+
 	if err := c.Sources.Git.validate(); err != nil {
 		return err
 	}
@@ -50,6 +79,14 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.Sources.Helm.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Sources.HTTP.validate(); err != nil {
+		return err
+	}
+
 	return c.Credentials.validate()
 }
 
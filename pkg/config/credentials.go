@@ -25,6 +25,7 @@ const (
 	CredentialTypeAccessToken = CredentialType("access_token")
 	CredentialTypeGitHubApp   = CredentialType("github_app")
 	CredentialTypeDockerLocal = CredentialType("docker_local")
+	CredentialTypeGPG         = CredentialType("gpg")
 )
 
 type Credential struct {
@@ -33,6 +34,7 @@ type Credential struct {
 	SSH         *SSHAuthConfig   `glu:"ssh"`
 	AccessToken *string          `glu:"access_token"`
 	GitHubApp   *GitHubAppConfig `glu:"github_app"`
+	GPG         *GPGConfig       `glu:"gpg"`
 }
 
 func (c *Credential) validate() error {
@@ -48,6 +50,8 @@ func (c *Credential) validate() error {
 	case CredentialTypeGitHubApp:
 		return c.GitHubApp.validate()
 	case CredentialTypeDockerLocal:
+	case CredentialTypeGPG:
+		return c.GPG.validate()
 	default:
 		return fmt.Errorf("unexpected credential type %q", c.Type)
 	}
@@ -102,11 +106,15 @@ func (a *SSHAuthConfig) validate() (err error) {
 	return nil
 }
 
+// GitHubAppConfig provides configuration for authenticating as a GitHub App installation.
+// APIURL should be set for GitHub Enterprise Server (e.g. https://github.example.com/api/v3)
+// so that installation tokens are minted from the enterprise endpoint.
 type GitHubAppConfig struct {
 	AppID           int64  `glu:"app_id"`
 	InstallationID  int64  `glu:"installation_id"`
 	PrivateKeyBytes string `glu:"private_key_bytes"`
 	PrivateKeyPath  string `glu:"private_key_path"`
+	APIURL          string `glu:"api_url"`
 }
 
 func (c *GitHubAppConfig) validate() (err error) {
@@ -130,3 +138,28 @@ func (c *GitHubAppConfig) validate() (err error) {
 
 	return nil
 }
+
+// GPGConfig provides an armored OpenPGP private key used for signing commits.
+type GPGConfig struct {
+	PrivateKeyBytes string `glu:"private_key_bytes"`
+	PrivateKeyPath  string `glu:"private_key_path"`
+	Passphrase      string `glu:"passphrase"`
+}
+
+func (c *GPGConfig) validate() (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("gpg: %w", err)
+		}
+	}()
+
+	if c == nil {
+		return errors.New("configuration is missing")
+	}
+
+	if (c.PrivateKeyBytes == "" && c.PrivateKeyPath == "") || (c.PrivateKeyBytes != "" && c.PrivateKeyPath != "") {
+		return errors.New("please provide exclusively one of private_key_bytes or private_key_path")
+	}
+
+	return nil
+}
@@ -1,6 +1,11 @@
 package config
 
-import "log/slog"
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
 
 type GitRepositories map[string]*Repository
 
@@ -29,9 +34,37 @@ type Repository struct {
 	DefaultBranch string     `glu:"default_branch"`
 	Remote        *Remote    `glu:"remote"`
 	Proposals     *Proposals `glu:"proposals"`
+	Signing       *Signing   `glu:"signing"`
+	// PollInterval (when non-zero) is the period between fetches from the remote
+	PollInterval time.Duration `glu:"poll_interval"`
+}
+
+// Signing configures commit signing for a repository.
+// The referenced credential must be of type gpg (OpenPGP signatures)
+// or ssh (SSH signatures).
+type Signing struct {
+	Credential string `glu:"credential"`
 }
 
 func (r *Repository) validate() error {
+	if r.PollInterval < 0 {
+		return errors.New("poll_interval: must not be negative")
+	}
+
+	if r.Signing != nil && r.Signing.Credential == "" {
+		return errors.New("signing: field credential is required")
+	}
+
+	if r.Proposals != nil {
+		if r.Remote == nil {
+			return errors.New("proposals: requires a remote")
+		}
+
+		if err := r.Proposals.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -50,6 +83,12 @@ func (r *Repository) setDefaults() error {
 		}
 	}
 
+	if r.Proposals != nil {
+		if err := r.Proposals.setDefaults(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -59,6 +98,42 @@ type Remote struct {
 	Credential string `glu:"credential"`
 }
 
+// ProposalsType is the SCM used to open proposals.
+type ProposalsType string
+
+const (
+	ProposalsTypeGitHub    = ProposalsType("github")
+	ProposalsTypeGitLab    = ProposalsType("gitlab")
+	ProposalsTypeGitea     = ProposalsType("gitea")
+	ProposalsTypeBitbucket = ProposalsType("bitbucket")
+)
+
+// Proposals configures the SCM used to open proposals (PRs/MRs) for a repository.
+// The API URL is derived from the remote URL when not provided, except for GitHub
+// where it defaults to api.github.com and must be set for GitHub Enterprise Server.
+// The upload URL is only used by GitHub Enterprise Server and defaults to the uploads API on the API URLs host.
 type Proposals struct {
-	Credential string `glu:"credential"`
+	Type       ProposalsType `glu:"type"`
+	APIURL     string        `glu:"api_url"`
+	UploadURL  string        `glu:"upload_url"`
+	Credential string        `glu:"credential"`
+}
+
+func (p *Proposals) setDefaults() error {
+	if p.Type == "" {
+		slog.Debug("setting missing default", "repository.proposals.type", ProposalsTypeGitHub)
+
+		p.Type = ProposalsTypeGitHub
+	}
+
+	return nil
+}
+
+func (p *Proposals) validate() error {
+	switch p.Type {
+	case ProposalsTypeGitHub, ProposalsTypeGitLab, ProposalsTypeGitea, ProposalsTypeBitbucket:
+		return nil
+	default:
+		return fmt.Errorf("proposals: unexpected type %q", p.Type)
+	}
 }
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/get-glu/glu/internal/semver"
+)
+
+type HelmRepositories map[string]*HelmRepository
+
+func (h HelmRepositories) setDefaults() error {
+	for name, repo := range h {
+		if err := repo.setDefaults(); err != nil {
+			return fmt.Errorf("helm %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (h HelmRepositories) validate() error {
+	for name, repo := range h {
+		if err := repo.validate(); err != nil {
+			return fmt.Errorf("helm %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// HelmRepository identifies a chart in either a classic HTTP chart repository
+// (e.g. https://charts.example.com) or an OCI registry (e.g. oci://registry.example.com/charts).
+// Version is a semver constraint (e.g. ">=1.2.0 <2.0.0" or "~1.4") and defaults to the latest stable version.
+type HelmRepository struct {
+	URL        string `glu:"url"`
+	Chart      string `glu:"chart"`
+	Version    string `glu:"version"`
+	Credential string `glu:"credential"`
+}
+
+func (h *HelmRepository) setDefaults() error {
+	return nil
+}
+
+func (h *HelmRepository) validate() error {
+	if h.URL == "" {
+		return errors.New("field url is required")
+	}
+
+	u, err := url.Parse(h.URL)
+	if err != nil {
+		return fmt.Errorf("url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "oci":
+	default:
+		return fmt.Errorf("url: unexpected scheme %q (expected one of [http https oci])", u.Scheme)
+	}
+
+	if h.Chart == "" {
+		return errors.New("field chart is required")
+	}
+
+	if _, err := semver.ParseConstraint(h.Version); err != nil {
+		return fmt.Errorf("version: %w", err)
+	}
+
+	return nil
+}
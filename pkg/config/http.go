@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+type HTTPEndpoints map[string]*HTTPEndpoint
+
+func (h HTTPEndpoints) setDefaults() error {
+	for name, endpoint := range h {
+		if err := endpoint.setDefaults(); err != nil {
+			return fmt.Errorf("http %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (h HTTPEndpoints) validate() error {
+	for name, endpoint := range h {
+		if err := endpoint.validate(); err != nil {
+			return fmt.Errorf("http %q: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// HTTPEndpoint is a URL which is polled (via GET) for the current state of a resource.
+// Headers are sent with every request (e.g. Accept: application/json).
+type HTTPEndpoint struct {
+	URL        string            `glu:"url"`
+	Headers    map[string]string `glu:"headers"`
+	Credential string            `glu:"credential"`
+}
+
+func (h *HTTPEndpoint) setDefaults() error {
+	return nil
+}
+
+func (h *HTTPEndpoint) validate() error {
+	if h.URL == "" {
+		return errors.New("field url is required")
+	}
+
+	u, err := url.Parse(h.URL)
+	if err != nil {
+		return fmt.Errorf("url: %w", err)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return fmt.Errorf("url: unexpected scheme %q (expected one of [http https])", u.Scheme)
+	}
+
+	return nil
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"errors"
 	"fmt"
+	"regexp"
+
+	"github.com/get-glu/glu/internal/semver"
 )
 
 type OCIRepositories map[string]*OCIRepository
@@ -28,8 +31,79 @@ func (o OCIRepositories) validate() error {
 }
 
 type OCIRepository struct {
-	Reference  string `glu:"reference"`
-	Credential string `glu:"credential"`
+	Reference  string     `glu:"reference"`
+	Credential string     `glu:"credential"`
+	Tags       *OCITags   `glu:"tags"`
+	Verify     *OCIVerify `glu:"verify"`
+}
+
+// OCITags selects the highest tag in the repository instead of resolving the tag in the reference.
+// Semver is a constraint (e.g. "~1.4" or ">=1.2.0 <2.0.0") and Pattern is a regular expression
+// which tags must match. When only Pattern is provided, matching tags are ordered by semver
+// where they parse as one and naturally otherwise (e.g. build-10 > build-9).
+// Pre-release versions are only selected when Prerelease is true (or the constraint names one).
+type OCITags struct {
+	Semver     string `glu:"semver"`
+	Pattern    string `glu:"pattern"`
+	Prerelease bool   `glu:"prerelease"`
+}
+
+func (o *OCITags) validate() error {
+	if o.Semver == "" && o.Pattern == "" {
+		return errors.New("one of semver or pattern is required")
+	}
+
+	if _, err := semver.ParseConstraint(o.Semver); err != nil {
+		return fmt.Errorf("semver: %w", err)
+	}
+
+	if _, err := regexp.Compile(o.Pattern); err != nil {
+		return fmt.Errorf("pattern: %w", err)
+	}
+
+	return nil
+}
+
+// OCIVerify requires resolved digests to be signed by at least one of the configured keys
+// before they are surfaced. Signatures are discovered as cosign signature tags (sha256-<digest>.sig)
+// and OCI referrers. Attestations lists in-toto predicate types (e.g. "https://slsa.dev/provenance/v1")
+// which must also be attested (sha256-<digest>.att) by one of the keys.
+type OCIVerify struct {
+	Keys         []*OCIVerifyKey `glu:"keys"`
+	Attestations []string        `glu:"attestations"`
+}
+
+func (o *OCIVerify) validate() error {
+	if len(o.Keys) == 0 {
+		return errors.New("at least one key is required")
+	}
+
+	for i, key := range o.Keys {
+		if err := key.validate(); err != nil {
+			return fmt.Errorf("keys[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// OCIVerifyKey is a named PEM encoded (PKIX) ECDSA, RSA or Ed25519 public key.
+type OCIVerifyKey struct {
+	Name           string `glu:"name"`
+	PublicKeyBytes string `glu:"public_key_bytes"`
+	PublicKeyPath  string `glu:"public_key_path"`
+}
+
+func (k *OCIVerifyKey) validate() error {
+	if k.Name == "" {
+		return errors.New("field name is required")
+	}
+
+	if (k.PublicKeyBytes == "" && k.PublicKeyPath == "") || (k.PublicKeyBytes != "" && k.PublicKeyPath != "") {
+		return errors.New("please provide exclusively one of public_key_bytes or public_key_path")
+	}
+
+	return nil
 }
 
 func (o *OCIRepository) setDefaults() error {
@@ -41,5 +115,17 @@ func (o *OCIRepository) validate() error {
 		return errors.New("field reference is required")
 	}
 
+	if o.Tags != nil {
+		if err := o.Tags.validate(); err != nil {
+			return fmt.Errorf("tags: %w", err)
+		}
+	}
+
+	if o.Verify != nil {
+		if err := o.Verify.validate(); err != nil {
+			return fmt.Errorf("verify: %w", err)
+		}
+	}
+
 	return nil
 }
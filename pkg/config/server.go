@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Server configures the HTTP API server started when a system is run in server mode.
+type Server struct {
+	Address             string        `glu:"address"`
+	BasePath            string        `glu:"base_path"`
+	TLS                 *TLS          `glu:"tls"`
+	ReadTimeout         time.Duration `glu:"read_timeout"`
+	WriteTimeout        time.Duration `glu:"write_timeout"`
+	IdleTimeout         time.Duration `glu:"idle_timeout"`
+	ShutdownGracePeriod time.Duration `glu:"shutdown_grace_period"`
+	CORS                CORS          `glu:"cors"`
+}
+
+// CORS configures which cross-origin browser clients (e.g. the UI when served separately)
+// may call the API. When no origins are configured, then only same-origin requests are permitted.
+// Origins may contain a single wildcard (e.g. https://*.example.com).
+type CORS struct {
+	AllowedOrigins   []string `glu:"allowed_origins"`
+	AllowCredentials bool     `glu:"allow_credentials"`
+}
+
+// TLS configures the servers certificate and key.
+// Both files are watched and the certificate is reloaded when either changes.
+// When a client CA file is configured, client certificates signed by it are
+// verified and can be used to authenticate with the API.
+type TLS struct {
+	CertFile     string `glu:"cert_file"`
+	KeyFile      string `glu:"key_file"`
+	ClientCAFile string `glu:"client_ca_file"`
+}
+
+func (s *Server) setDefaults() error {
+	if s.Address == "" {
+		slog.Debug("setting missing default", "server.address", ":8080")
+
+		s.Address = ":8080"
+	}
+
+	if s.ShutdownGracePeriod == 0 {
+		slog.Debug("setting missing default", "server.shutdown_grace_period", "15s")
+
+		s.ShutdownGracePeriod = 15 * time.Second
+	}
+
+	// normalize base path to the form /some/prefix
+	if s.BasePath = strings.Trim(s.BasePath, "/"); s.BasePath != "" {
+		s.BasePath = "/" + s.BasePath
+	}
+
+	return nil
+}
+
+func (s *Server) validate() error {
+	for name, d := range map[string]time.Duration{
+		"read_timeout":          s.ReadTimeout,
+		"write_timeout":         s.WriteTimeout,
+		"idle_timeout":          s.IdleTimeout,
+		"shutdown_grace_period": s.ShutdownGracePeriod,
+	} {
+		if d < 0 {
+			return fmt.Errorf("server: field %s must not be negative", name)
+		}
+	}
+
+	if s.TLS != nil {
+		if s.TLS.CertFile == "" || s.TLS.KeyFile == "" {
+			return errors.New("server: tls requires both cert_file and key_file")
+		}
+	}
+
+	if s.CORS.AllowCredentials && slices.Contains(s.CORS.AllowedOrigins, "*") {
+		return errors.New("server: cors allow_credentials cannot be used with allowed origin \"*\"")
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package core
+
+import "context"
+
+// Actor identifies who (or what) initiated an operation such as a promotion.
+// Sources can use the actor to attribute the changes they make (e.g. as a commit author).
+type Actor struct {
+	// Name is the human readable name of the actor
+	Name string `json:"name,omitempty"`
+	// Email is the optional email address of the actor
+	Email string `json:"email,omitempty"`
+	// Trigger is the mechanism through which the actor initiated the operation
+	// (e.g. "cli", "api" or "schedule")
+	Trigger string `json:"trigger,omitempty"`
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx which carries the provided actor.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx (if any).
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
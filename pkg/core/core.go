@@ -3,7 +3,10 @@ package core
 import (
 	"context"
 	"errors"
+	"fmt"
 	"iter"
+	"strings"
+	"time"
 
 	"github.com/get-glu/glu/pkg/containers"
 )
@@ -14,6 +17,12 @@ var (
 	// ErrAlreadyExists is returned when an attempt is made to create a resource
 	// which already exists
 	ErrAlreadyExists = errors.New("already exists")
+	// ErrNotSupported is returned when an operation is not supported
+	// by the underlying source of a phase
+	ErrNotSupported = errors.New("not supported")
+	// ErrUnverified is returned when the provenance (e.g. signature) of
+	// a resource state could not be verified
+	ErrUnverified = errors.New("unverified")
 )
 
 // Metadata contains the unique information used to identify
@@ -26,11 +35,54 @@ type Metadata struct {
 
 // Pipeline is a collection of phases with potential promotion dependencies
 // relationships between one another.
+// Dependencies returns the edges of the pipelines graph, mapping each phase
+// to the (possibly empty) set of upstream phases it promotes from.
 type Pipeline interface {
 	Metadata() Metadata
 	PhaseByName(string) (Phase, error)
 	Phases(...containers.Option[PhaseOptions]) iter.Seq[Phase]
-	Dependencies() map[Phase]Phase
+	Dependencies() map[Phase][]Phase
+	PromoteAll(context.Context, ...containers.Option[PromoteAllOptions]) error
+	Validate() error
+}
+
+// ValidationError is returned when a pipeline is found to be incorrectly configured.
+// It contains every problem found during validation.
+type ValidationError struct {
+	Pipeline string
+	Errs     []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Errs))
+	for _, err := range e.Errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	return fmt.Sprintf("pipeline %q is invalid: %s", e.Pipeline, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Unwrap() []error {
+	return e.Errs
+}
+
+// PromoteAllOptions configures a call to promote all the phases in a pipeline.
+type PromoteAllOptions struct {
+	ContinueOnError bool
+	PhaseOptions    []containers.Option[PhaseOptions]
+}
+
+// ContinueOnError causes a call to PromoteAll to continue promoting the remaining
+// phases after a failure, as opposed to stopping at the first.
+func ContinueOnError(o *PromoteAllOptions) {
+	o.ContinueOnError = true
+}
+
+// PromoteMatching scopes a call to PromoteAll to the phases which match the provided options.
+func PromoteMatching(opts ...containers.Option[PhaseOptions]) containers.Option[PromoteAllOptions] {
+	return func(o *PromoteAllOptions) {
+		o.PhaseOptions = append(o.PhaseOptions, opts...)
+	}
 }
 
 // Resource is an instance of a resource in a phase.
@@ -49,15 +101,174 @@ type Phase interface {
 	Promote(context.Context) error
 }
 
+// State is a snapshot of a resource in a phase at a particular
+// revision of the phases underlying source.
+type State struct {
+	Revision  string    `json:"revision"`
+	Digest    string    `json:"digest"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message,omitempty"`
+	Resource  any       `json:"resource,omitempty"`
+}
+
+// HistoricalPhase is a Phase which can list the previous states of its resource
+// and rollback to any one of them.
+type HistoricalPhase interface {
+	Phase
+	History(context.Context) ([]State, error)
+	Rollback(_ context.Context, revision string) error
+}
+
+// Pin describes the state at which a phase has been frozen.
+type Pin struct {
+	Digest   string    `json:"digest"`
+	Revision string    `json:"revision,omitempty"`
+	PinnedAt time.Time `json:"pinned_at"`
+}
+
+// PinnablePhase is a Phase which can be pinned to a particular state.
+// Pinned phases are skipped during promotion until they're unpinned.
+type PinnablePhase interface {
+	Phase
+	Pin(_ context.Context, revision string) error
+	Unpin(context.Context) error
+	Pinned(context.Context) (*Pin, error)
+}
+
+// Plan describes the change which would be applied were a phase promoted.
+type Plan struct {
+	FromDigest string       `json:"from_digest"`
+	ToDigest   string       `json:"to_digest"`
+	Diff       string       `json:"diff,omitempty"`
+	Gates      []GateResult `json:"gates,omitempty"`
+}
+
+// PlannablePhase is a Phase which can describe the change a promotion would make
+// without applying it (a dry-run).
+// Plan returns nil when there is no promotion pending.
+type PlannablePhase interface {
+	Phase
+	Plan(context.Context) (*Plan, error)
+}
+
+// Proposal is an open change proposal (PR/MR) for a phase.
+type Proposal struct {
+	Number       int    `json:"number,omitempty"`
+	URL          string `json:"url,omitempty"`
+	Branch       string `json:"branch"`
+	BaseBranch   string `json:"base_branch"`
+	BaseRevision string `json:"base_revision,omitempty"`
+	Digest       string `json:"digest"`
+	// Checks is the combined state of the proposals checks (pending, success or failure)
+	// It is empty when the status of the proposal is not known
+	Checks    string `json:"checks,omitempty"`
+	Approvals int    `json:"approvals,omitempty"`
+	// Mergeable is nil when the status of the proposal is not known
+	Mergeable *bool `json:"mergeable,omitempty"`
+}
+
+// ProposalPhase is a Phase which can list the open change proposals for itself.
+type ProposalPhase interface {
+	Phase
+	Proposals(context.Context) ([]Proposal, error)
+}
+
+// Verification is the outcome of verifying the provenance (e.g. signatures) of a phases current state.
+type Verification struct {
+	Digest   string `json:"digest"`
+	Verified bool   `json:"verified"`
+	// Signers are the names of the keys which produced valid signatures
+	Signers []string `json:"signers,omitempty"`
+	// Attestations are the predicate types of verified attestations
+	Attestations []string `json:"attestations,omitempty"`
+	// Reason describes why the state could not be verified
+	Reason string `json:"reason,omitempty"`
+}
+
+// VerifiablePhase is a Phase which verifies the provenance of its current state.
+// Verification returns nil when the phase is not configured to verify.
+type VerifiablePhase interface {
+	Phase
+	Verification(context.Context) (*Verification, error)
+}
+
+// PrunablePhase is a Phase which can close any outstanding change proposals (PR/MR)
+// which no longer represent its pending promotion.
+// Prune returns the names of the branches of the closed proposals.
+// Prunable returns the names of the branches Prune would close, without closing them.
+type PrunablePhase interface {
+	Phase
+	Prune(context.Context) ([]string, error)
+	Prunable(context.Context) ([]string, error)
+}
+
 // AddPhaseOptions are used to configure the addition of a ResourcePhase to a Pipeline
 type AddPhaseOptions[R Resource] struct {
-	PromotedFrom ResourcePhase[R]
+	PromotedFrom []ResourcePhase[R]
+	Strategy     MergeStrategy
+	Gates        []Gate
+}
+
+// MergeStrategyType identifies how a phase selects the resource to promote
+// when it promotes from multiple upstream phases.
+type MergeStrategyType string
+
+const (
+	// MergeAllEqual only promotes when every upstream phase agrees on the same digest
+	MergeAllEqual = MergeStrategyType("all-equal")
+	// MergeAny promotes from the first upstream phase with a digest which differs from the current
+	MergeAny = MergeStrategyType("any")
+	// MergeQuorum promotes when at-least a quorum of upstream phases agree on the same digest
+	MergeQuorum = MergeStrategyType("quorum")
+)
+
+// MergeStrategy decides which upstream resource (if any) a phase promotes from.
+// The zero value is equivalent to MergeAllEqual.
+type MergeStrategy struct {
+	Type   MergeStrategyType `json:"type"`
+	Quorum int               `json:"quorum,omitempty"`
 }
 
 // PromotesFrom configures a dependent Phase to promote from for the Phase being added.
+// It can be supplied multiple times to configure multiple upstream phases (see PromotesFromAll).
 func PromotesFrom[R Resource](c ResourcePhase[R]) containers.Option[AddPhaseOptions[R]] {
 	return func(o *AddPhaseOptions[R]) {
-		o.PromotedFrom = c
+		o.PromotedFrom = append(o.PromotedFrom, c)
+	}
+}
+
+// PromotesFromAll configures the Phase being added to promote from all the provided phases.
+// Promotion only occurs once all of the upstream phases agree on the same digest.
+func PromotesFromAll[R Resource](c ...ResourcePhase[R]) containers.Option[AddPhaseOptions[R]] {
+	return func(o *AddPhaseOptions[R]) {
+		o.PromotedFrom = append(o.PromotedFrom, c...)
+		o.Strategy = MergeStrategy{Type: MergeAllEqual}
+	}
+}
+
+// PromotesFromAny configures the Phase being added to promote from any of the provided phases.
+// Promotion occurs from the first upstream phase (in the order provided) with a differing digest.
+func PromotesFromAny[R Resource](c ...ResourcePhase[R]) containers.Option[AddPhaseOptions[R]] {
+	return func(o *AddPhaseOptions[R]) {
+		o.PromotedFrom = append(o.PromotedFrom, c...)
+		o.Strategy = MergeStrategy{Type: MergeAny}
+	}
+}
+
+// PromotesFromQuorum configures the Phase being added to promote from the provided phases.
+// Promotion only occurs once at-least n of the upstream phases agree on the same digest.
+func PromotesFromQuorum[R Resource](n int, c ...ResourcePhase[R]) containers.Option[AddPhaseOptions[R]] {
+	return func(o *AddPhaseOptions[R]) {
+		o.PromotedFrom = append(o.PromotedFrom, c...)
+		o.Strategy = MergeStrategy{Type: MergeQuorum, Quorum: n}
+	}
+}
+
+// WithGates configures a set of gates which must all allow a promotion
+// before the Phase being added is updated.
+func WithGates[R Resource](gates ...Gate) containers.Option[AddPhaseOptions[R]] {
+	return func(o *AddPhaseOptions[R]) {
+		o.Gates = append(o.Gates, gates...)
 	}
 }
 
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrPromotionHeld is returned when one or more gates did not allow a promotion to proceed.
+	ErrPromotionHeld = errors.New("promotion held")
+	// ErrPromotionDenied is additionally returned when one or more gates rejected
+	// the promotion outright, as opposed to holding it until a later attempt.
+	ErrPromotionDenied = errors.New("promotion denied")
+)
+
+// GateDecision is the outcome of evaluating a Gate.
+type GateDecision string
+
+const (
+	// GateAllow permits the promotion to proceed
+	GateAllow = GateDecision("allow")
+	// GateDeny rejects the promotion
+	GateDeny = GateDecision("deny")
+	// GateWait holds the promotion until a subsequent attempt
+	GateWait = GateDecision("wait")
+)
+
+// GateResult is the decision (and the reason for it) produced by a named Gate.
+type GateResult struct {
+	Gate     string       `json:"gate"`
+	Decision GateDecision `json:"decision"`
+	Reason   string       `json:"reason,omitempty"`
+}
+
+// GateRequest describes a pending promotion which is evaluated by a Gate.
+// From is the current state of the resource in the promoting phase.
+// To is the state of the resource in the upstream phase being promoted from.
+// Promoting is true when the gates are evaluated for an attempted promotion, as opposed
+// to when they are read (e.g. to inspect or plan a phase). Gates should only record
+// state (such as a pending approval) when it is true.
+type GateRequest struct {
+	Pipeline  Metadata
+	Phase     Metadata
+	Upstream  Phase
+	From      Resource
+	To        Resource
+	Promoting bool
+}
+
+// Gate is a condition which is evaluated before a phase is promoted.
+// It decides whether the promotion should be allowed, denied or held until later.
+type Gate interface {
+	Name() string
+	Evaluate(context.Context, GateRequest) (GateResult, error)
+}
+
+// GatedPhase is a Phase which can report the results of evaluating its gates
+// against the currently pending promotion.
+type GatedPhase interface {
+	Phase
+	EvaluateGates(context.Context) ([]GateResult, error)
+}
+
+// GateError is returned when a promotion is held by one or more gates.
+// It unwraps to ErrPromotionHeld and, when any gate denied the promotion, ErrPromotionDenied.
+type GateError struct {
+	Results []GateResult
+}
+
+// Decision returns GateDeny if any gate denied the promotion and GateWait otherwise.
+func (e *GateError) Decision() GateDecision {
+	for _, result := range e.Results {
+		if result.Decision == GateDeny {
+			return GateDeny
+		}
+	}
+
+	return GateWait
+}
+
+func (e *GateError) Error() string {
+	var reasons []string
+	for _, result := range e.Results {
+		if result.Decision == GateAllow {
+			continue
+		}
+
+		reasons = append(reasons, fmt.Sprintf("%s (%s): %s", result.Gate, result.Decision, result.Reason))
+	}
+
+	prefix := ErrPromotionHeld
+	if e.Decision() == GateDeny {
+		prefix = ErrPromotionDenied
+	}
+
+	return fmt.Sprintf("%s: %s", prefix, strings.Join(reasons, ", "))
+}
+
+func (e *GateError) Unwrap() []error {
+	if e.Decision() == GateDeny {
+		return []error{ErrPromotionHeld, ErrPromotionDenied}
+	}
+
+	return []error{ErrPromotionHeld}
+}
+
+// Allowed returns true if none of the results deny or hold the promotion.
+func Allowed(results []GateResult) bool {
+	for _, result := range results {
+		if result.Decision != GateAllow {
+			return false
+		}
+	}
+
+	return true
+}
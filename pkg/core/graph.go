@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCycle is returned when the dependency graph of a pipeline contains a cycle
+var ErrCycle = errors.New("cycle detected")
+
+// TopologicalSort orders the provided phases such that each phase appears after
+// all of its upstream dependencies (as described by deps).
+// Otherwise, the relative order of the supplied phases is preserved.
+// Dependencies on phases which are not in the supplied set are ignored.
+// It returns an error wrapping ErrCycle if the graph contains a cycle.
+func TopologicalSort(phases []Phase, deps map[Phase][]Phase) ([]Phase, error) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	var (
+		state  = make(map[Phase]int, len(phases))
+		sorted = make([]Phase, 0, len(phases))
+		visit  func(Phase) error
+	)
+
+	for _, phase := range phases {
+		state[phase] = 0
+	}
+
+	visit = func(phase Phase) error {
+		switch state[phase] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("phase %q: %w", phase.Metadata().Name, ErrCycle)
+		}
+
+		state[phase] = visiting
+		for _, dep := range deps[phase] {
+			if _, ok := state[dep]; !ok {
+				continue
+			}
+
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+
+		state[phase] = visited
+		sorted = append(sorted, phase)
+
+		return nil
+	}
+
+	for _, phase := range phases {
+		if err := visit(phase); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted, nil
+}
@@ -7,6 +7,10 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/get-glu/glu/pkg/config"
@@ -41,25 +45,54 @@ func (s *CredentialSource) Get(name string) (*Credential, error) {
 type Credential struct {
 	config *config.Credential
 
-	transport *ghinstallation.Transport
+	mu         sync.Mutex
+	transports map[string]*ghinstallation.Transport
 }
 
-func (c *Credential) GitHubClient(ctx context.Context) (_ *github.Client, err error) {
+// GitHubClient returns a GitHub API client authenticated using the credential.
+// When apiURL is non-empty (or the credential is a GitHub App with an api_url)
+// the client targets GitHub Enterprise Server at that URL.
+// The uploadURL defaults to the uploads endpoint on the API URLs host when empty.
+func (c *Credential) GitHubClient(ctx context.Context, apiURL, uploadURL string) (client *github.Client, err error) {
 	if c.config.Type == config.CredentialTypeGitHubApp {
-		transport, err := c.githubInstallationTransport()
+		var baseURL string
+		if apiURL == "" {
+			apiURL = c.config.GitHubApp.APIURL
+		} else if c.config.GitHubApp.APIURL == "" {
+			// mint installation tokens from the same enterprise endpoint
+			baseURL = enterpriseAPIURL(apiURL)
+		}
+
+		transport, err := c.githubInstallationTransport(baseURL)
+		if err != nil {
+			return nil, err
+		}
+
+		client = github.NewClient(&http.Client{Transport: transport})
+	} else {
+		httpClient, err := c.HTTPClient(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		return github.NewClient(&http.Client{Transport: transport}), nil
+		client = github.NewClient(httpClient)
 	}
 
-	client, err := c.HTTPClient(ctx)
-	if err != nil {
-		return nil, err
+	if apiURL == "" {
+		return client, nil
+	}
+
+	if uploadURL == "" {
+		u, err := url.Parse(apiURL)
+		if err != nil {
+			return nil, fmt.Errorf("github: parsing api url: %w", err)
+		}
+
+		// the client appends api/uploads/ to the host
+		uploadURL = u.Scheme + "://" + u.Host + "/"
 	}
 
-	return github.NewClient(client), nil
+	return client.WithEnterpriseURLs(apiURL, uploadURL)
 }
 
 func (c *Credential) HTTPClient(ctx context.Context) (*http.Client, error) {
@@ -86,12 +119,12 @@ func (c *Credential) HTTPClient(ctx context.Context) (*http.Client, error) {
 func (c *Credential) GitAuthentication() (auth transport.AuthMethod, err error) {
 	switch c.config.Type {
 	case config.CredentialTypeGitHubApp:
-		transport, err := c.githubInstallationTransport()
-		if err != nil {
+		// validate the key eagerly, the transport used is resolved per request
+		if _, err := c.githubInstallationTransport(""); err != nil {
 			return nil, err
 		}
 
-		return &ghAppInstallation{transport}, nil
+		return &ghAppInstallation{c}, nil
 	case config.CredentialTypeBasic:
 		return &githttp.BasicAuth{
 			Username: c.config.Basic.Username,
@@ -138,6 +171,40 @@ func (c *Credential) GitAuthentication() (auth transport.AuthMethod, err error)
 	return nil, fmt.Errorf("git: unxpected credential type: %q", c.config.Type)
 }
 
+// CommitSigner returns a git commit signer for gpg (OpenPGP) and ssh credentials.
+func (c *Credential) CommitSigner() (Signer, error) {
+	switch c.config.Type {
+	case config.CredentialTypeGPG:
+		conf := c.config.GPG
+
+		key, err := keyBytes(conf.PrivateKeyBytes, conf.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewOpenPGPSigner(key, conf.Passphrase)
+	case config.CredentialTypeSSH:
+		conf := c.config.SSH
+
+		key, err := keyBytes(conf.PrivateKeyBytes, conf.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSSHSigner(key, conf.Password)
+	}
+
+	return nil, fmt.Errorf("signing: unexpected credential type: %q", c.config.Type)
+}
+
+func keyBytes(raw, path string) ([]byte, error) {
+	if raw != "" {
+		return []byte(raw), nil
+	}
+
+	return os.ReadFile(path)
+}
+
 func (c *Credential) OCIClient(registry string) (_ *auth.Client, err error) {
 	var creds auth.CredentialFunc
 	switch c.config.Type {
@@ -165,7 +232,7 @@ func (c *Credential) OCIClient(registry string) (_ *auth.Client, err error) {
 }
 
 type ghAppInstallation struct {
-	transport *ghinstallation.Transport
+	credential *Credential
 }
 
 func (i *ghAppInstallation) String() string {
@@ -177,7 +244,20 @@ func (i *ghAppInstallation) Name() string {
 }
 
 func (i *ghAppInstallation) SetAuth(r *http.Request) {
-	token, err := i.transport.Token(r.Context())
+	// when the app has no configured api_url, tokens for a remote
+	// other than github.com are minted from that hosts enterprise API
+	var baseURL string
+	if i.credential.config.GitHubApp.APIURL == "" && r.URL.Hostname() != "github.com" {
+		baseURL = enterpriseAPIURL("https://" + r.URL.Host)
+	}
+
+	transport, err := i.credential.githubInstallationTransport(baseURL)
+	if err != nil {
+		slog.Error("Attempting to build GitHub app installation transport", "error", err)
+		return
+	}
+
+	token, err := transport.Token(r.Context())
 	if err != nil {
 		slog.Error("Attempting to fetch GitHub app installation token", "error", err)
 		return
@@ -186,16 +266,28 @@ func (i *ghAppInstallation) SetAuth(r *http.Request) {
 	r.SetBasicAuth("x-access-token", token)
 }
 
-func (c *Credential) githubInstallationTransport() (_ *ghinstallation.Transport, err error) {
-	if c.transport != nil {
-		return c.transport, nil
+// githubInstallationTransport returns the installation transport which mints tokens from baseURL.
+// When baseURL is empty the credentials api_url (or api.github.com) is used.
+// Transports are cached per base URL and never modified once constructed,
+// as they are shared between git authentication and API clients.
+func (c *Credential) githubInstallationTransport(baseURL string) (_ *ghinstallation.Transport, err error) {
+	conf := c.config.GitHubApp
+	if baseURL == "" && conf.APIURL != "" {
+		baseURL = enterpriseAPIURL(conf.APIURL)
 	}
 
-	conf := c.config.GitHubApp
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if transport, ok := c.transports[baseURL]; ok {
+		return transport, nil
+	}
+
+	var transport *ghinstallation.Transport
 	if len(conf.PrivateKeyBytes) > 0 {
-		c.transport, err = ghinstallation.New(http.DefaultTransport, conf.AppID, conf.InstallationID, []byte(conf.PrivateKeyBytes))
+		transport, err = ghinstallation.New(http.DefaultTransport, conf.AppID, conf.InstallationID, []byte(conf.PrivateKeyBytes))
 	} else if conf.PrivateKeyPath != "" {
-		c.transport, err = ghinstallation.NewKeyFromFile(http.DefaultTransport, conf.AppID, conf.InstallationID, conf.PrivateKeyPath)
+		transport, err = ghinstallation.NewKeyFromFile(http.DefaultTransport, conf.AppID, conf.InstallationID, conf.PrivateKeyPath)
 	} else {
 		return nil, errors.New("github_app auth: neither private key bytes nor path was provided")
 	}
@@ -203,5 +295,26 @@ func (c *Credential) githubInstallationTransport() (_ *ghinstallation.Transport,
 		return nil, err
 	}
 
-	return c.transport, nil
+	if baseURL != "" {
+		transport.BaseURL = baseURL
+	}
+
+	if c.transports == nil {
+		c.transports = map[string]*ghinstallation.Transport{}
+	}
+
+	c.transports[baseURL] = transport
+
+	return transport, nil
+}
+
+// enterpriseAPIURL normalizes a GitHub Enterprise Server URL to the form
+// https://<host>/api/v3 (as expected by ghinstallation).
+func enterpriseAPIURL(u string) string {
+	u = strings.TrimSuffix(u, "/")
+	if !strings.HasSuffix(u, "/api/v3") {
+		u += "/api/v3"
+	}
+
+	return u
 }
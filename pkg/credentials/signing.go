@@ -0,0 +1,160 @@
+package credentials
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+// Signer produces the detached signature of a message (e.g. an encoded git commit).
+type Signer interface {
+	Sign(message io.Reader) ([]byte, error)
+}
+
+type openPGPSigner struct {
+	entity *openpgp.Entity
+}
+
+// NewOpenPGPSigner returns a Signer which produces armored detached OpenPGP signatures
+// using the first private key found in the provided armored key ring.
+// The passphrase is used to decrypt the key when it is encrypted.
+func NewOpenPGPSigner(armored []byte, passphrase string) (Signer, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(armored))
+	if err != nil {
+		return nil, fmt.Errorf("reading openpgp key: %w", err)
+	}
+
+	for _, entity := range entities {
+		if entity.PrivateKey == nil {
+			continue
+		}
+
+		if passphrase != "" {
+			if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+				return nil, fmt.Errorf("decrypting openpgp key: %w", err)
+			}
+		}
+
+		if entity.PrivateKey.Encrypted {
+			return nil, errors.New("openpgp key is encrypted and no passphrase was provided")
+		}
+
+		return &openPGPSigner{entity: entity}, nil
+	}
+
+	return nil, errors.New("openpgp private key not found")
+}
+
+func (s *openPGPSigner) Sign(message io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&buf, s.entity, message, nil); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sshSigNamespace is the namespace used by git for SSH commit signatures.
+const sshSigNamespace = "git"
+
+type sshSigner struct {
+	signer ssh.Signer
+}
+
+// NewSSHSigner returns a Signer which produces SSH signatures (as produced by
+// ssh-keygen -Y sign and understood by git's gpg.format=ssh) using the provided
+// PEM encoded private key. The passphrase is only used when the key is encrypted.
+func NewSSHSigner(pemBytes []byte, passphrase string) (Signer, error) {
+	signer, err := ssh.ParsePrivateKey(pemBytes)
+
+	var missing *ssh.PassphraseMissingError
+	if errors.As(err, &missing) && passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(pemBytes, []byte(passphrase))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("parsing ssh key: %w", err)
+	}
+
+	return &sshSigner{signer: signer}, nil
+}
+
+// Sign produces an armored signature in the SSHSIG format.
+// See https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+func (s *sshSigner) Sign(message io.Reader) ([]byte, error) {
+	h := sha512.New()
+	if _, err := io.Copy(h, message); err != nil {
+		return nil, err
+	}
+
+	signed := append([]byte("SSHSIG"), sshsigBlob(
+		[]byte(sshSigNamespace),
+		nil, // reserved
+		[]byte("sha512"),
+		h.Sum(nil),
+	)...)
+
+	var (
+		sig *ssh.Signature
+		err error
+	)
+
+	// RSA keys must use a SHA-2 based signature algorithm
+	if algSigner, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		sig, err = algSigner.SignWithAlgorithm(rand.Reader, signed, ssh.KeyAlgoRSASHA512)
+	} else {
+		sig, err = s.signer.Sign(rand.Reader, signed)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var blob bytes.Buffer
+	blob.WriteString("SSHSIG")
+	_ = binary.Write(&blob, binary.BigEndian, uint32(1))
+	blob.Write(sshsigBlob(
+		s.signer.PublicKey().Marshal(),
+		[]byte(sshSigNamespace),
+		nil, // reserved
+		[]byte("sha512"),
+		ssh.Marshal(sig),
+	))
+
+	return armorSSHSig(blob.Bytes()), nil
+}
+
+// sshsigBlob encodes each field as an SSH wire format string.
+func sshsigBlob(fields ...[]byte) []byte {
+	var buf bytes.Buffer
+	for _, field := range fields {
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(field)))
+		buf.Write(field)
+	}
+
+	return buf.Bytes()
+}
+
+func armorSSHSig(blob []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(blob)
+
+	var buf bytes.Buffer
+	buf.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		buf.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+
+	buf.WriteString(encoded + "\n")
+	buf.WriteString("-----END SSH SIGNATURE-----\n")
+
+	return buf.Bytes()
+}
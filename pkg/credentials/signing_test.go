@@ -0,0 +1,247 @@
+package credentials
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"golang.org/x/crypto/ssh"
+)
+
+const message = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nsigned commit\n"
+
+func TestSSHSigner(t *testing.T) {
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name       string
+		key        crypto.PrivateKey
+		encrypt    string
+		passphrase string
+		algorithm  string
+	}{
+		{name: "ed25519", key: ed25519Key, algorithm: ssh.KeyAlgoED25519},
+		{name: "ed25519 with unused passphrase", key: ed25519Key, passphrase: "secret", algorithm: ssh.KeyAlgoED25519},
+		{name: "encrypted ed25519", key: ed25519Key, encrypt: "secret", passphrase: "secret", algorithm: ssh.KeyAlgoED25519},
+		{name: "rsa", key: rsaKey, algorithm: ssh.KeyAlgoRSASHA512},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			signer, err := NewSSHSigner(marshalSSHKey(t, test.key, test.encrypt), test.passphrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			armored, err := signer.Sign(strings.NewReader(message))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			verifySSHSig(t, armored, []byte(message), test.algorithm)
+		})
+	}
+}
+
+func TestNewSSHSigner_Errors(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted := marshalSSHKey(t, key, "secret")
+
+	if _, err := NewSSHSigner(encrypted, ""); err == nil {
+		t.Error("expected error for encrypted key without passphrase")
+	}
+
+	if _, err := NewSSHSigner(encrypted, "wrong"); err == nil {
+		t.Error("expected error for encrypted key with incorrect passphrase")
+	}
+}
+
+func TestOpenPGPSigner(t *testing.T) {
+	entity, err := openpgp.NewEntity("glu", "", "glu@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// serialize the signed identities before the keys are encrypted
+	var plain bytes.Buffer
+	if err := entity.SerializePrivate(&plain, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.EncryptPrivateKeys([]byte("secret"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var encrypted bytes.Buffer
+	if err := entity.SerializePrivateWithoutSigning(&encrypted, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	keyring, err := openpgp.ReadKeyRing(bytes.NewReader(plain.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name       string
+		key        []byte
+		passphrase string
+	}{
+		{name: "unencrypted", key: armorPGPKey(t, plain.Bytes())},
+		{name: "encrypted", key: armorPGPKey(t, encrypted.Bytes()), passphrase: "secret"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			signer, err := NewOpenPGPSigner(test.key, test.passphrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signature, err := signer.Sign(strings.NewReader(message))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := openpgp.CheckArmoredDetachedSignature(keyring, strings.NewReader(message), bytes.NewReader(signature), nil); err != nil {
+				t.Errorf("verifying signature: %v", err)
+			}
+
+			if _, err := openpgp.CheckArmoredDetachedSignature(keyring, strings.NewReader(message+"tampered"), bytes.NewReader(signature), nil); err == nil {
+				t.Error("expected signature over a different message to be rejected")
+			}
+		})
+	}
+
+	if _, err := NewOpenPGPSigner(armorPGPKey(t, encrypted.Bytes()), ""); err == nil {
+		t.Error("expected error for encrypted key without passphrase")
+	}
+}
+
+// verifySSHSig verifies an armored SSHSIG signature over message in the "git" namespace
+// in the same manner as ssh-keygen -Y verify.
+func verifySSHSig(t *testing.T, armored, message []byte, algorithm string) {
+	t.Helper()
+
+	encoded := strings.TrimSpace(string(armored))
+	encoded, ok := strings.CutPrefix(encoded, "-----BEGIN SSH SIGNATURE-----\n")
+	if !ok {
+		t.Fatalf("unexpected signature armor %q", armored)
+	}
+
+	encoded, ok = strings.CutSuffix(encoded, "\n-----END SSH SIGNATURE-----")
+	if !ok {
+		t.Fatalf("unexpected signature armor %q", armored)
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\n", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob, ok = bytes.CutPrefix(blob, []byte("SSHSIG"))
+	if !ok {
+		t.Fatal("signature is missing the SSHSIG preamble")
+	}
+
+	var sshsig struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}
+
+	if err := ssh.Unmarshal(blob, &sshsig); err != nil {
+		t.Fatal(err)
+	}
+
+	if sshsig.Version != 1 || sshsig.Namespace != "git" || sshsig.HashAlgorithm != "sha512" {
+		t.Fatalf("unexpected signature fields %+v", sshsig)
+	}
+
+	key, err := ssh.ParsePublicKey(sshsig.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var signature ssh.Signature
+	if err := ssh.Unmarshal(sshsig.Signature, &signature); err != nil {
+		t.Fatal(err)
+	}
+
+	if signature.Format != algorithm {
+		t.Errorf("expected signature algorithm %q, found %q", algorithm, signature.Format)
+	}
+
+	digest := sha512.Sum512(message)
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{"git", "", "sha512", digest[:]})...)
+
+	if err := key.Verify(signed, &signature); err != nil {
+		t.Errorf("verifying signature: %v", err)
+	}
+}
+
+func marshalSSHKey(t *testing.T, key crypto.PrivateKey, passphrase string) []byte {
+	t.Helper()
+
+	var (
+		block *pem.Block
+		err   error
+	)
+
+	if passphrase != "" {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte(passphrase))
+	} else {
+		block, err = ssh.MarshalPrivateKey(key, "")
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(block)
+}
+
+func armorPGPKey(t *testing.T, key []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := w.Write(key); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
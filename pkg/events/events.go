@@ -0,0 +1,117 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Type identifies the kind of an Event.
+type Type string
+
+const (
+	// PromotionStarted is published when a phase begins updating its source
+	PromotionStarted = Type("promotion.started")
+	// PromotionFinished is published when a phase successfully updates its source
+	PromotionFinished = Type("promotion.finished")
+	// PromotionFailed is published when a phase fails to update its source
+	PromotionFailed = Type("promotion.failed")
+	// PhaseChanged is published when the state of a phase may have changed
+	PhaseChanged = Type("phase.changed")
+	// ProposalOpened is published when a source opens a new proposal (PR/MR)
+	ProposalOpened = Type("proposal.opened")
+	// ProposalRebased is published when a source rebuilds a conflicted proposal on its base
+	ProposalRebased = Type("proposal.rebased")
+	// ProposalMerged is published when a source merges a proposal
+	ProposalMerged = Type("proposal.merged")
+	// ProposalClosed is published when a source closes a superseded or stale proposal
+	ProposalClosed = Type("proposal.closed")
+)
+
+// Event is a notification of something which occurred within a pipeline phase.
+type Event struct {
+	Type       Type              `json:"type"`
+	Pipeline   string            `json:"pipeline,omitempty"`
+	Phase      string            `json:"phase,omitempty"`
+	Timestamp  time.Time         `json:"timestamp"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// Publisher is a type which can receive published events.
+type Publisher interface {
+	Publish(Event)
+}
+
+type publisherKey struct{}
+
+// WithPublisher returns a copy of ctx which carries the provided publisher.
+// Calls to Publish with the returned context (or any of its children) are
+// delivered to the publisher.
+func WithPublisher(ctx context.Context, p Publisher) context.Context {
+	return context.WithValue(ctx, publisherKey{}, p)
+}
+
+// Publish delivers the event to the publisher carried by ctx (if any).
+// The events timestamp is set to the current time when left empty.
+func Publish(ctx context.Context, e Event) {
+	p, ok := ctx.Value(publisherKey{}).(Publisher)
+	if !ok {
+		return
+	}
+
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+
+	p.Publish(e)
+}
+
+var _ Publisher = (*Bus)(nil)
+
+// Bus is a Publisher which fans out published events to any number of subscribers.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[chan Event]struct{}
+}
+
+// NewBus constructs a new *Bus with no subscribers.
+func NewBus() *Bus {
+	return &Bus{subs: map[chan Event]struct{}{}}
+}
+
+// Publish delivers the event to every current subscriber.
+// Delivery never blocks and so events are dropped for subscribers which
+// are not keeping up.
+func (b *Bus) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subs {
+		select {
+		case sub <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel on which all subsequently published events are delivered.
+// The subscription is removed and the channel closed once ctx is done.
+func (b *Bus) Subscribe(ctx context.Context) <-chan Event {
+	sub := make(chan Event, 64)
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.subs, sub)
+		close(sub)
+	}()
+
+	return sub
+}
@@ -0,0 +1,255 @@
+package gates
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/get-glu/glu/pkg/containers"
+	"github.com/get-glu/glu/pkg/core"
+)
+
+var (
+	_ core.Gate = (*SoakGate)(nil)
+	_ core.Gate = (*WindowGate)(nil)
+	_ core.Gate = (*HTTPProbeGate)(nil)
+)
+
+// SoakGate holds a promotion until the upstream resource state
+// has been present in the upstream phase for a minimum duration.
+type SoakGate struct {
+	minimum time.Duration
+	now     func() time.Time
+}
+
+// MinimumSoak returns a gate which holds promotions until the promoted
+// resource state has soaked in the upstream phase for at-least d.
+// The upstream phase must implement core.HistoricalPhase.
+func MinimumSoak(d time.Duration) *SoakGate {
+	return &SoakGate{minimum: d, now: time.Now}
+}
+
+func (g *SoakGate) Name() string {
+	return "minimum-soak"
+}
+
+// Evaluate locates when the promoted digest was introduced into the upstream phase
+// and waits until the minimum soak duration has elapsed since.
+func (g *SoakGate) Evaluate(ctx context.Context, req core.GateRequest) (core.GateResult, error) {
+	historical, ok := req.Upstream.(core.HistoricalPhase)
+	if !ok {
+		return core.GateResult{
+			Decision: core.GateDeny,
+			Reason:   fmt.Sprintf("upstream phase %q does not support history", req.Upstream.Metadata().Name),
+		}, nil
+	}
+
+	digest, err := req.To.Digest()
+	if err != nil {
+		return core.GateResult{}, err
+	}
+
+	states, err := historical.History(ctx)
+	if err != nil {
+		return core.GateResult{}, err
+	}
+
+	for _, state := range states {
+		if state.Digest != digest {
+			continue
+		}
+
+		soaked := g.now().Sub(state.Timestamp)
+		if soaked < g.minimum {
+			return core.GateResult{
+				Decision: core.GateWait,
+				Reason:   fmt.Sprintf("soaked for %s of %s", soaked.Truncate(time.Second), g.minimum),
+			}, nil
+		}
+
+		return core.GateResult{
+			Decision: core.GateAllow,
+			Reason:   fmt.Sprintf("soaked for %s", soaked.Truncate(time.Second)),
+		}, nil
+	}
+
+	return core.GateResult{
+		Decision: core.GateWait,
+		Reason:   fmt.Sprintf("digest %q not found in upstream history", digest),
+	}, nil
+}
+
+// WindowGate holds promotions which occur outside of a configured time-of-day window.
+type WindowGate struct {
+	start, end time.Duration
+	days       []time.Weekday
+	location   *time.Location
+	now        func() time.Time
+}
+
+// Window returns a gate which only allows promotions between start and end.
+// Both start and end are offsets from midnight (e.g. 9*time.Hour for 09:00).
+// When end is before start, then the window is considered to span midnight.
+// By default, the window applies every day in UTC.
+func Window(start, end time.Duration, opts ...containers.Option[WindowGate]) *WindowGate {
+	gate := &WindowGate{
+		start:    start,
+		end:      end,
+		location: time.UTC,
+		now:      time.Now,
+	}
+
+	containers.ApplyAll(gate, opts...)
+
+	return gate
+}
+
+// OnDays restricts the window to the provided days of the week.
+func OnDays(days ...time.Weekday) containers.Option[WindowGate] {
+	return func(g *WindowGate) {
+		g.days = days
+	}
+}
+
+// InLocation evaluates the window in the provided location.
+func InLocation(loc *time.Location) containers.Option[WindowGate] {
+	return func(g *WindowGate) {
+		g.location = loc
+	}
+}
+
+func (g *WindowGate) Name() string {
+	return "time-window"
+}
+
+// Evaluate allows the promotion if the current time falls within the window.
+func (g *WindowGate) Evaluate(_ context.Context, _ core.GateRequest) (core.GateResult, error) {
+	var (
+		now = g.now().In(g.location)
+		// offset is the wall clock time of day, as opposed to the time elapsed since
+		// midnight, so that windows are unaffected by daylight saving transitions
+		offset = time.Duration(now.Hour())*time.Hour +
+			time.Duration(now.Minute())*time.Minute +
+			time.Duration(now.Second())*time.Second +
+			time.Duration(now.Nanosecond())
+		window = fmt.Sprintf("%s-%s %s", clock(g.start), clock(g.end), g.location)
+	)
+
+	if len(g.days) > 0 && !slices.Contains(g.days, now.Weekday()) {
+		return core.GateResult{
+			Decision: core.GateWait,
+			Reason:   fmt.Sprintf("%s is outside of allowed days %v", now.Weekday(), g.days),
+		}, nil
+	}
+
+	inside := offset >= g.start && offset < g.end
+	if g.end < g.start {
+		// window spans midnight
+		inside = offset >= g.start || offset < g.end
+	}
+
+	if !inside {
+		return core.GateResult{
+			Decision: core.GateWait,
+			Reason:   fmt.Sprintf("%s is outside of window %s", now.Format("15:04"), window),
+		}, nil
+	}
+
+	return core.GateResult{
+		Decision: core.GateAllow,
+		Reason:   fmt.Sprintf("within window %s", window),
+	}, nil
+}
+
+func clock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// HTTPProbeGate holds promotions until a HTTP endpoint reports healthy.
+type HTTPProbeGate struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+	healthy func(*http.Response) bool
+}
+
+// HTTPProbe returns a gate which performs a GET request against the provided URL.
+// By default, the promotion is allowed when the endpoint responds with a 2xx status code.
+func HTTPProbe(url string, opts ...containers.Option[HTTPProbeGate]) *HTTPProbeGate {
+	gate := &HTTPProbeGate{
+		url:     url,
+		client:  http.DefaultClient,
+		timeout: 10 * time.Second,
+		healthy: func(resp *http.Response) bool {
+			return resp.StatusCode >= 200 && resp.StatusCode < 300
+		},
+	}
+
+	containers.ApplyAll(gate, opts...)
+
+	return gate
+}
+
+// WithHTTPClient overrides the client used to perform the probe.
+func WithHTTPClient(client *http.Client) containers.Option[HTTPProbeGate] {
+	return func(g *HTTPProbeGate) {
+		g.client = client
+	}
+}
+
+// WithTimeout overrides the timeout for a single probe request (default 10s).
+func WithTimeout(d time.Duration) containers.Option[HTTPProbeGate] {
+	return func(g *HTTPProbeGate) {
+		g.timeout = d
+	}
+}
+
+// WithExpectedStatus overrides the default healthy condition with an
+// exact match on one of the provided status codes.
+func WithExpectedStatus(codes ...int) containers.Option[HTTPProbeGate] {
+	return func(g *HTTPProbeGate) {
+		g.healthy = func(resp *http.Response) bool {
+			return slices.Contains(codes, resp.StatusCode)
+		}
+	}
+}
+
+func (g *HTTPProbeGate) Name() string {
+	return "http-probe"
+}
+
+// Evaluate performs the probe request and allows the promotion if the response is healthy.
+// Failed requests and unhealthy responses hold the promotion until a later attempt.
+func (g *HTTPProbeGate) Evaluate(ctx context.Context, _ core.GateRequest) (core.GateResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.url, nil)
+	if err != nil {
+		return core.GateResult{}, err
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return core.GateResult{
+			Decision: core.GateWait,
+			Reason:   fmt.Sprintf("probe %s failed: %v", g.url, err),
+		}, nil
+	}
+
+	defer resp.Body.Close()
+
+	if !g.healthy(resp) {
+		return core.GateResult{
+			Decision: core.GateWait,
+			Reason:   fmt.Sprintf("probe %s unhealthy: %s", g.url, resp.Status),
+		}, nil
+	}
+
+	return core.GateResult{
+		Decision: core.GateAllow,
+		Reason:   fmt.Sprintf("probe %s healthy: %s", g.url, resp.Status),
+	}, nil
+}
@@ -2,11 +2,15 @@ package phases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/get-glu/glu/pkg/containers"
 	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/events"
 )
 
 // Source is an interface around storage for resources.
@@ -20,19 +24,84 @@ type UpdatableSource[R core.Resource] interface {
 	Update(_ context.Context, pipeline, phase core.Metadata, from, to R) error
 }
 
+// HistorySource is a Source which can list and view the previous states
+// of a resource for a phase.
+type HistorySource[R core.Resource] interface {
+	Source[R]
+	History(_ context.Context, pipeline, phase core.Metadata, newFn func() R) ([]core.State, error)
+	ViewRevision(_ context.Context, pipeline, phase core.Metadata, revision string, _ R) error
+}
+
+// PinSource is a Source which can durably persist the pin state of a phase.
+// GetPin returns nil when the phase is not pinned and a call to SetPin with nil clears the pin.
+type PinSource[R core.Resource] interface {
+	Source[R]
+	GetPin(_ context.Context, pipeline, phase core.Metadata) (*core.Pin, error)
+	SetPin(_ context.Context, pipeline, phase core.Metadata, _ *core.Pin) error
+}
+
+// PlanSource is an UpdatableSource which can describe the changes an update would make
+// as a unified diff, without applying them.
+type PlanSource[R core.Resource] interface {
+	UpdatableSource[R]
+	Plan(_ context.Context, pipeline, phase core.Metadata, from, to R) (string, error)
+}
+
+// WatchableSource is a Source which can notify when the state of a phase may have changed.
+// The provided function is called with a context carrying any configured events publisher.
+type WatchableSource[R core.Resource] interface {
+	Source[R]
+	Watch(pipeline, phase core.Metadata, fn func(context.Context))
+}
+
+// ProposalSource is a Source which can list the open change proposals for a phase.
+type ProposalSource[R core.Resource] interface {
+	Source[R]
+	Proposals(_ context.Context, pipeline, phase core.Metadata) ([]core.Proposal, error)
+}
+
+// VerifiableSource is a Source which verifies the provenance of the state of a phase.
+type VerifiableSource[R core.Resource] interface {
+	Source[R]
+	Verification(_ context.Context, pipeline, phase core.Metadata) (*core.Verification, error)
+}
+
+// PrunableSource is a Source which can close outstanding change proposals for a phase.
+// Every proposal which does not propose the pending digest is closed ("" when nothing is pending).
+// When dryRun is true, the proposals are returned without being closed.
+type PrunableSource[R core.Resource] interface {
+	Source[R]
+	Prune(_ context.Context, pipeline, phase core.Metadata, pending string, dryRun bool) ([]string, error)
+}
+
 // Pipeline is a set of phase with promotion dependencies between one another.
 type Pipeline[R core.Resource] interface {
 	New() R
 	Metadata() core.Metadata
 	Add(r core.ResourcePhase[R], opts ...containers.Option[core.AddPhaseOptions[R]]) error
-	PromotedFrom(core.ResourcePhase[R]) (core.ResourcePhase[R], bool)
+	PromotedFrom(core.ResourcePhase[R]) ([]core.ResourcePhase[R], core.MergeStrategy)
 }
 
+var (
+	_ core.HistoricalPhase = (*Phase[core.Resource])(nil)
+	_ core.PinnablePhase   = (*Phase[core.Resource])(nil)
+	_ core.GatedPhase      = (*Phase[core.Resource])(nil)
+	_ core.PlannablePhase  = (*Phase[core.Resource])(nil)
+	_ core.PrunablePhase   = (*Phase[core.Resource])(nil)
+	_ core.ProposalPhase   = (*Phase[core.Resource])(nil)
+	_ core.VerifiablePhase = (*Phase[core.Resource])(nil)
+)
+
 type Phase[R core.Resource] struct {
 	logger   *slog.Logger
 	meta     core.Metadata
 	pipeline Pipeline[R]
 	source   Source[R]
+	gates    []core.Gate
+
+	// prunedFor is the pending digest proposals were last pruned for during promotion
+	prunedMu  sync.Mutex
+	prunedFor *string
 }
 
 func New[R core.Resource](meta core.Metadata, pipeline Pipeline[R], repo Source[R], opts ...containers.Option[core.AddPhaseOptions[R]]) (*Phase[R], error) {
@@ -41,17 +110,27 @@ func New[R core.Resource](meta core.Metadata, pipeline Pipeline[R], repo Source[
 		logger = logger.With(k, v)
 	}
 
+	var add core.AddPhaseOptions[R]
+	containers.ApplyAll(&add, opts...)
+
 	phase := &Phase[R]{
 		logger:   logger,
 		meta:     meta,
 		pipeline: pipeline,
 		source:   repo,
+		gates:    add.Gates,
 	}
 
 	if err := pipeline.Add(phase, opts...); err != nil {
 		return nil, err
 	}
 
+	if watchable, ok := repo.(WatchableSource[R]); ok {
+		watchable.Watch(pipeline.Metadata(), meta, func(ctx context.Context) {
+			phase.publish(ctx, events.PhaseChanged, nil)
+		})
+	}
+
 	return phase, nil
 }
 
@@ -80,7 +159,9 @@ func (i *Phase[R]) GetResource(ctx context.Context) (a R, err error) {
 // Promote causes the phase to attempt a promotion from a dependent phase.
 // If there is no promotion phase, this process is skipped.
 // The phase fetches both its current resource state, and that of the promotion source phase.
-// If the resources differ, then the phase updates its source to match the promoted version.
+// If the resources differ, then the phase evaluates any configured gates.
+// Given every gate allows it, the phase updates its source to match the promoted version.
+// Otherwise, a *core.GateError is returned describing why the promotion was held.
 func (i *Phase[R]) Promote(ctx context.Context) (err error) {
 	i.logger.Debug("Promotion started")
 	defer func() {
@@ -95,21 +176,301 @@ func (i *Phase[R]) Promote(ctx context.Context) (err error) {
 		return nil
 	}
 
-	from := i.pipeline.New()
-	if err := i.source.View(ctx, i.pipeline.Metadata(), i.meta, from); err != nil {
+	pin, err := i.Pinned(ctx)
+	if err != nil && !errors.Is(err, core.ErrNotSupported) {
 		return err
 	}
 
-	dep, ok := i.pipeline.PromotedFrom(i)
-	if !ok {
+	if pin != nil {
+		i.logger.Info("skipping promotion", "reason", "Pinned", "digest", pin.Digest)
+
+		return nil
+	}
+
+	p, err := i.pending(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending string
+	if p != nil {
+		pending = p.toDigest
+	}
+
+	i.pruneOnChange(ctx, pending)
+
+	if p == nil {
 		return nil
 	}
 
-	to, err := dep.GetResource(ctx)
+	results, err := i.evaluateGates(ctx, p, true)
 	if err != nil {
 		return err
 	}
 
+	if !core.Allowed(results) {
+		return &core.GateError{Results: results}
+	}
+
+	attrs := map[string]string{"from_digest": p.fromDigest, "to_digest": p.toDigest}
+	i.publish(ctx, events.PromotionStarted, attrs)
+
+	if err := updatable.Update(ctx, i.pipeline.Metadata(), i.meta, p.from, p.to); err != nil {
+		i.publish(ctx, events.PromotionFailed, map[string]string{
+			"from_digest": p.fromDigest,
+			"to_digest":   p.toDigest,
+			"error":       err.Error(),
+		})
+
+		return fmt.Errorf("updating from %q to %q: %w", p.fromDigest, p.toDigest, err)
+	}
+
+	i.publish(ctx, events.PromotionFinished, attrs)
+
+	return nil
+}
+
+func (i *Phase[R]) publish(ctx context.Context, typ events.Type, attrs map[string]string) {
+	events.Publish(ctx, events.Event{
+		Type:       typ,
+		Pipeline:   i.pipeline.Metadata().Name,
+		Phase:      i.meta.Name,
+		Attributes: attrs,
+	})
+}
+
+// EvaluateGates evaluates the phases gates against the currently pending promotion.
+// It returns no results when there is nothing to promote.
+func (i *Phase[R]) EvaluateGates(ctx context.Context) ([]core.GateResult, error) {
+	p, err := i.pending(ctx)
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	return i.evaluateGates(ctx, p, false)
+}
+
+// Plan describes the promotion which would occur if the phase were promoted now,
+// including the results of evaluating any gates.
+// When the source supports it, the plan contains a unified diff of the changes.
+// It returns nil if there is no promotion pending.
+func (i *Phase[R]) Plan(ctx context.Context) (_ *core.Plan, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("planning %s/%s: %w", i.pipeline.Metadata().Name, i.meta.Name, err)
+		}
+	}()
+
+	p, err := i.pending(ctx)
+	if err != nil || p == nil {
+		return nil, err
+	}
+
+	plan := &core.Plan{
+		FromDigest: p.fromDigest,
+		ToDigest:   p.toDigest,
+	}
+
+	plan.Gates, err = i.evaluateGates(ctx, p, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if source, ok := i.source.(PlanSource[R]); ok {
+		plan.Diff, err = source.Plan(ctx, i.pipeline.Metadata(), i.meta, p.from, p.to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// promotion is a pending change from one resource state to another.
+type promotion[R core.Resource] struct {
+	upstream   core.ResourcePhase[R]
+	from, to   R
+	fromDigest string
+	toDigest   string
+}
+
+// pending returns the promotion which would occur if the phase were promoted now.
+// It returns nil if there is no upstream phase, the upstream phases do not satisfy
+// the configured merge strategy or the resources are already equal.
+func (i *Phase[R]) pending(ctx context.Context) (*promotion[R], error) {
+	from := i.pipeline.New()
+	if err := i.source.View(ctx, i.pipeline.Metadata(), i.meta, from); err != nil {
+		return nil, err
+	}
+
+	deps, strategy := i.pipeline.PromotedFrom(i)
+	if len(deps) == 0 {
+		return nil, nil
+	}
+
+	fromDigest, err := from.Digest()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]candidate[R], 0, len(deps))
+	for _, dep := range deps {
+		to, err := dep.GetResource(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		toDigest, err := to.Digest()
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, candidate[R]{dep, to, toDigest})
+	}
+
+	selected, reason := merge(strategy, fromDigest, candidates)
+	if selected == nil {
+		i.logger.Debug("skipping promotion", "reason", reason)
+
+		return nil, nil
+	}
+
+	if fromDigest == selected.digest {
+		i.logger.Debug("skipping promotion", "reason", "UpToDate")
+
+		return nil, nil
+	}
+
+	return &promotion[R]{
+		upstream:   selected.phase,
+		from:       from,
+		to:         selected.resource,
+		fromDigest: fromDigest,
+		toDigest:   selected.digest,
+	}, nil
+}
+
+// candidate is the current state of an upstream phase.
+type candidate[R core.Resource] struct {
+	phase    core.ResourcePhase[R]
+	resource R
+	digest   string
+}
+
+// merge applies the strategy to the upstream candidates and returns the one to promote from.
+// It returns nil (along with a reason) when the strategy is not satisfied.
+func merge[R core.Resource](strategy core.MergeStrategy, current string, candidates []candidate[R]) (*candidate[R], string) {
+	switch strategy.Type {
+	case core.MergeAny:
+		for _, c := range candidates {
+			if c.digest != current {
+				return &c, ""
+			}
+		}
+
+		return &candidates[0], ""
+	case core.MergeQuorum:
+		var (
+			counts = map[string]int{}
+			best   *candidate[R]
+		)
+
+		for _, c := range candidates {
+			counts[c.digest]++
+			if best == nil || counts[c.digest] > counts[best.digest] {
+				best = &c
+			}
+		}
+
+		if counts[best.digest] < strategy.Quorum {
+			return nil, "QuorumNotReached"
+		}
+
+		return best, ""
+	default:
+		for _, c := range candidates[1:] {
+			if c.digest != candidates[0].digest {
+				return nil, "UpstreamDisagree"
+			}
+		}
+
+		return &candidates[0], ""
+	}
+}
+
+func (i *Phase[R]) evaluateGates(ctx context.Context, p *promotion[R], promoting bool) ([]core.GateResult, error) {
+	req := core.GateRequest{
+		Pipeline:  i.pipeline.Metadata(),
+		Phase:     i.meta,
+		Upstream:  p.upstream,
+		From:      p.from,
+		To:        p.to,
+		Promoting: promoting,
+	}
+
+	results := make([]core.GateResult, 0, len(i.gates))
+	for _, gate := range i.gates {
+		result, err := gate.Evaluate(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating gate %q: %w", gate.Name(), err)
+		}
+
+		if result.Gate == "" {
+			result.Gate = gate.Name()
+		}
+
+		i.logger.Debug("gate evaluated", "gate", result.Gate, "decision", result.Decision, "reason", result.Reason)
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// History returns the previous states of the phases resource (most recent first).
+// It returns core.ErrNotSupported if the underlying source cannot list its history.
+func (i *Phase[R]) History(ctx context.Context) ([]core.State, error) {
+	source, ok := i.source.(HistorySource[R])
+	if !ok {
+		return nil, fmt.Errorf("history for source %q: %w", i.source.Type(), core.ErrNotSupported)
+	}
+
+	return source.History(ctx, i.pipeline.Metadata(), i.meta, i.pipeline.New)
+}
+
+// Rollback updates the phases source to match the state of the resource
+// found at the provided revision.
+// It returns core.ErrNotSupported if the underlying source cannot be updated
+// or cannot view previous revisions.
+func (i *Phase[R]) Rollback(ctx context.Context, revision string) (err error) {
+	i.logger.Debug("Rollback started", "revision", revision)
+	defer func() {
+		i.logger.Debug("Rollback finished", "revision", revision)
+		if err != nil {
+			err = fmt.Errorf("rolling back %s/%s: %w", i.pipeline.Metadata().Name, i.meta.Name, err)
+		}
+	}()
+
+	updatable, ok := i.source.(UpdatableSource[R])
+	if !ok {
+		return fmt.Errorf("update source %q: %w", i.source.Type(), core.ErrNotSupported)
+	}
+
+	history, ok := i.source.(HistorySource[R])
+	if !ok {
+		return fmt.Errorf("history for source %q: %w", i.source.Type(), core.ErrNotSupported)
+	}
+
+	from := i.pipeline.New()
+	if err := i.source.View(ctx, i.pipeline.Metadata(), i.meta, from); err != nil {
+		return err
+	}
+
+	to := i.pipeline.New()
+	if err := history.ViewRevision(ctx, i.pipeline.Metadata(), i.meta, revision, to); err != nil {
+		return err
+	}
+
 	fromDigest, err := from.Digest()
 	if err != nil {
 		return err
@@ -121,7 +482,7 @@ func (i *Phase[R]) Promote(ctx context.Context) (err error) {
 	}
 
 	if fromDigest == toDigest {
-		i.logger.Debug("skipping promotion", "reason", "UpToDate")
+		i.logger.Debug("skipping rollback", "reason", "UpToDate")
 
 		return nil
 	}
@@ -132,3 +493,152 @@ func (i *Phase[R]) Promote(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// Pin freezes the phase at its current state, or at the state found at the provided
+// revision when it is non-empty (in which case the phase is first rolled back).
+// Pinned phases are skipped during promotion until they're unpinned.
+// It returns core.ErrNotSupported if the underlying source cannot persist pins.
+func (i *Phase[R]) Pin(ctx context.Context, revision string) (err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("pinning %s/%s: %w", i.pipeline.Metadata().Name, i.meta.Name, err)
+		}
+	}()
+
+	source, ok := i.source.(PinSource[R])
+	if !ok {
+		return fmt.Errorf("pin for source %q: %w", i.source.Type(), core.ErrNotSupported)
+	}
+
+	r := i.pipeline.New()
+	if revision == "" {
+		if err := i.source.View(ctx, i.pipeline.Metadata(), i.meta, r); err != nil {
+			return err
+		}
+	} else {
+		if err := i.Rollback(ctx, revision); err != nil {
+			return err
+		}
+
+		// rollback has already established that the source supports history
+		history := i.source.(HistorySource[R])
+		if err := history.ViewRevision(ctx, i.pipeline.Metadata(), i.meta, revision, r); err != nil {
+			return err
+		}
+	}
+
+	digest, err := r.Digest()
+	if err != nil {
+		return err
+	}
+
+	i.logger.Info("pinning phase", "digest", digest, "revision", revision)
+
+	return source.SetPin(ctx, i.pipeline.Metadata(), i.meta, &core.Pin{
+		Digest:   digest,
+		Revision: revision,
+		PinnedAt: time.Now().UTC(),
+	})
+}
+
+// Unpin clears any existing pin on the phase, allowing it to be promoted again.
+// It returns core.ErrNotSupported if the underlying source cannot persist pins.
+func (i *Phase[R]) Unpin(ctx context.Context) error {
+	source, ok := i.source.(PinSource[R])
+	if !ok {
+		return fmt.Errorf("pin for source %q: %w", i.source.Type(), core.ErrNotSupported)
+	}
+
+	i.logger.Info("unpinning phase")
+
+	return source.SetPin(ctx, i.pipeline.Metadata(), i.meta, nil)
+}
+
+// Pinned returns the current pin for the phase or nil if it is not pinned.
+// It returns core.ErrNotSupported if the underlying source cannot persist pins.
+func (i *Phase[R]) Pinned(ctx context.Context) (*core.Pin, error) {
+	source, ok := i.source.(PinSource[R])
+	if !ok {
+		return nil, fmt.Errorf("pin for source %q: %w", i.source.Type(), core.ErrNotSupported)
+	}
+
+	return source.GetPin(ctx, i.pipeline.Metadata(), i.meta)
+}
+
+// Prune closes any outstanding change proposals which do not represent the currently
+// pending promotion and returns the branches of those which were closed.
+// It returns core.ErrNotSupported if the underlying source does not manage proposals.
+func (i *Phase[R]) Prune(ctx context.Context) ([]string, error) {
+	return i.prunePending(ctx, false)
+}
+
+// Prunable returns the branches of the change proposals which Prune would close.
+// It returns core.ErrNotSupported if the underlying source does not manage proposals.
+func (i *Phase[R]) Prunable(ctx context.Context) ([]string, error) {
+	return i.prunePending(ctx, true)
+}
+
+func (i *Phase[R]) prunePending(ctx context.Context, dryRun bool) ([]string, error) {
+	p, err := i.pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending string
+	if p != nil {
+		pending = p.toDigest
+	}
+
+	return i.prune(ctx, pending, dryRun)
+}
+
+// pruneOnChange prunes proposals during promotion, at most once for each pending digest.
+// Proposals are only superseded when the pending promotion changes, so repeated promotions
+// of the same pending digest do not list proposals again.
+// Pruning is best effort and does not prevent promotion, a failed prune is retried on the next promotion.
+func (i *Phase[R]) pruneOnChange(ctx context.Context, pending string) {
+	i.prunedMu.Lock()
+	defer i.prunedMu.Unlock()
+
+	if i.prunedFor != nil && *i.prunedFor == pending {
+		return
+	}
+
+	if _, err := i.prune(ctx, pending, false); err != nil && !errors.Is(err, core.ErrNotSupported) {
+		i.logger.Warn("pruning proposals", "error", err)
+		return
+	}
+
+	i.prunedFor = &pending
+}
+
+func (i *Phase[R]) prune(ctx context.Context, pending string, dryRun bool) ([]string, error) {
+	source, ok := i.source.(PrunableSource[R])
+	if !ok {
+		return nil, fmt.Errorf("prune for source %q: %w", i.source.Type(), core.ErrNotSupported)
+	}
+
+	return source.Prune(ctx, i.pipeline.Metadata(), i.meta, pending, dryRun)
+}
+
+// Proposals returns the open change proposals for the phase.
+// It returns core.ErrNotSupported if the underlying source does not manage proposals.
+func (i *Phase[R]) Proposals(ctx context.Context) ([]core.Proposal, error) {
+	source, ok := i.source.(ProposalSource[R])
+	if !ok {
+		return nil, fmt.Errorf("proposals for source %q: %w", i.source.Type(), core.ErrNotSupported)
+	}
+
+	return source.Proposals(ctx, i.pipeline.Metadata(), i.meta)
+}
+
+// Verification returns the outcome of verifying the provenance of the phases current state.
+// It returns core.ErrNotSupported if the underlying source does not verify.
+func (i *Phase[R]) Verification(ctx context.Context) (*core.Verification, error) {
+	source, ok := i.source.(VerifiableSource[R])
+	if !ok {
+		return nil, fmt.Errorf("verification for source %q: %w", i.source.Type(), core.ErrNotSupported)
+	}
+
+	return source.Verification(ctx, i.pipeline.Metadata(), i.meta)
+}
@@ -0,0 +1,337 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/get-glu/glu/pkg/scm/internal/rest"
+	"github.com/get-glu/glu/pkg/src/git"
+)
+
+const (
+	BitbucketPRIDField = "bitbucket.pr.id"
+
+	pageLimit = 100
+)
+
+var _ git.Proposer = (*SCM)(nil)
+
+// SCM is a git.Proposer which manages proposals as Bitbucket Server (or Data Center)
+// pull requests via the REST 1.0 API.
+type SCM struct {
+	client     *rest.Client
+	projectKey string
+	repoSlug   string
+}
+
+// New constructs a Bitbucket proposer for the identified repository.
+// The apiURL is the base of the REST API (e.g. https://bitbucket.example.com/rest/api/1.0)
+// and the provided client is expected to authenticate requests (basic auth or an access token).
+func New(client *http.Client, apiURL, projectKey, repoSlug string) *SCM {
+	return &SCM{client: rest.New(client, apiURL), projectKey: projectKey, repoSlug: repoSlug}
+}
+
+type project struct {
+	Key string `json:"key"`
+}
+
+type repository struct {
+	Slug    string  `json:"slug"`
+	Project project `json:"project"`
+}
+
+type ref struct {
+	ID         string      `json:"id"`
+	DisplayID  string      `json:"displayId,omitempty"`
+	Repository *repository `json:"repository,omitempty"`
+}
+
+type reviewer struct {
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
+}
+
+type pullRequest struct {
+	ID          int        `json:"id,omitempty"`
+	Version     int        `json:"version,omitempty"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	FromRef     ref        `json:"fromRef"`
+	ToRef       ref        `json:"toRef"`
+	Reviewers   []reviewer `json:"reviewers,omitempty"`
+	Links       *links     `json:"links,omitempty"`
+}
+
+type links struct {
+	Self []struct {
+		Href string `json:"href"`
+	} `json:"self"`
+}
+
+// link returns the web URL of the pull request (if known).
+func (pr *pullRequest) link() string {
+	if pr.Links != nil && len(pr.Links.Self) > 0 {
+		return pr.Links.Self[0].Href
+	}
+
+	return ""
+}
+
+func (s *SCM) GetCurrentProposal(ctx context.Context, baseBranch, branchPrefix string) (*git.Proposal, error) {
+	var (
+		prs      = s.listPRs(ctx, baseBranch)
+		proposal *git.Proposal
+	)
+
+	for pr := range prs.All() {
+		if strings.HasPrefix(pr.FromRef.DisplayID, branchPrefix+"/") {
+			proposal = toProposal(pr)
+			break
+		}
+	}
+
+	if err := prs.Err(); err != nil {
+		return nil, err
+	}
+
+	if proposal == nil {
+		return nil, fmt.Errorf("base %q: prefix %q: %w", baseBranch, branchPrefix, git.ErrProposalNotFound)
+	}
+
+	if err := s.setBaseRevision(ctx, proposal); err != nil {
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+func (s *SCM) ListProposals(ctx context.Context, branchPrefix string) (proposals []*git.Proposal, err error) {
+	prs := s.listPRs(ctx, "")
+	for pr := range prs.All() {
+		if strings.HasPrefix(pr.FromRef.DisplayID, branchPrefix+"/") {
+			proposals = append(proposals, toProposal(pr))
+		}
+	}
+
+	if err := prs.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, proposal := range proposals {
+		if err := s.setBaseRevision(ctx, proposal); err != nil {
+			return nil, err
+		}
+	}
+
+	return proposals, nil
+}
+
+// setBaseRevision sets the proposals base revision to the commit its branch was created from.
+// The pull requests target ref only reports the current head of the base branch,
+// so the merge base of the source and target branches is resolved instead.
+func (s *SCM) setBaseRevision(ctx context.Context, proposal *git.Proposal) error {
+	var commit struct {
+		ID string `json:"id"`
+	}
+
+	if _, err := s.client.Do(ctx, http.MethodGet, s.prPath(proposal.Number)+"/merge-base", nil, nil, &commit); err != nil {
+		return fmt.Errorf("resolving merge base: %w", err)
+	}
+
+	proposal.BaseRevision = commit.ID
+
+	return nil
+}
+
+func toProposal(pr *pullRequest) *git.Proposal {
+	parts := strings.Split(pr.FromRef.DisplayID, "/")
+	return &git.Proposal{
+		Number:     pr.ID,
+		URL:        pr.link(),
+		BaseBranch: pr.ToRef.DisplayID,
+		Branch:     pr.FromRef.DisplayID,
+		Digest:     parts[len(parts)-1],
+		Title:      pr.Title,
+		Body:       pr.Description,
+		ExternalMetadata: map[string]any{
+			BitbucketPRIDField: pr.ID,
+		},
+	}
+}
+
+func (s *SCM) CreateProposal(ctx context.Context, proposal *git.Proposal, opts git.ProposalOption) error {
+	repo := &repository{Slug: s.repoSlug, Project: project{Key: s.projectKey}}
+
+	req := pullRequest{
+		Title:       proposal.Title,
+		Description: proposal.Body,
+		FromRef:     ref{ID: "refs/heads/" + proposal.Branch, Repository: repo},
+		ToRef:       ref{ID: "refs/heads/" + proposal.BaseBranch, Repository: repo},
+	}
+
+	for _, name := range opts.Reviewers {
+		var r reviewer
+		r.User.Name = name
+		req.Reviewers = append(req.Reviewers, r)
+	}
+
+	if len(opts.Labels) > 0 {
+		slog.Warn("skipping proposal labels", "scm_type", "bitbucket", "reason", "labels are not supported")
+	}
+
+	var pr pullRequest
+	if _, err := s.client.Do(ctx, http.MethodPost, s.repoPath()+"/pull-requests", nil, req, &pr); err != nil {
+		return err
+	}
+
+	slog.Info("proposal created", "scm_type", "bitbucket", "proposal_url", pr.link())
+
+	proposal.Number = pr.ID
+	proposal.URL = pr.link()
+	proposal.ExternalMetadata = map[string]any{
+		BitbucketPRIDField: pr.ID,
+	}
+
+	return nil
+}
+
+// strategies maps merge methods to Bitbucket merge strategy IDs.
+var strategies = map[git.MergeMethod]string{
+	git.MergeMethodMerge:  "no-ff",
+	git.MergeMethodSquash: "squash",
+	git.MergeMethodRebase: "rebase-no-ff",
+}
+
+func (s *SCM) MergeProposal(ctx context.Context, proposal *git.Proposal, opts git.MergeOption) error {
+	id, ok := proposal.ExternalMetadata[BitbucketPRIDField].(int)
+	if !ok {
+		slog.Warn("could not merge pr", "reason", "missing PR id on proposal")
+		return nil
+	}
+
+	var body any
+	if strategy, ok := strategies[opts.Method]; ok {
+		body = map[string]any{"strategyId": strategy}
+	}
+
+	return s.withVersion(ctx, id, "merge", body)
+}
+
+func (s *SCM) CloseProposal(ctx context.Context, proposal *git.Proposal, opts git.CloseOption) error {
+	id, ok := proposal.ExternalMetadata[BitbucketPRIDField].(int)
+	if !ok {
+		slog.Warn("could not decline pr", "reason", "missing PR id on proposal")
+		return nil
+	}
+
+	if opts.Comment != "" {
+		if _, err := s.client.Do(ctx, http.MethodPost, s.prPath(id)+"/comments", nil, map[string]any{
+			"text": opts.Comment,
+		}, nil); err != nil {
+			return err
+		}
+	}
+
+	return s.withVersion(ctx, id, "decline", nil)
+}
+
+// withVersion performs the provided pull request action, which requires the current
+// version of the pull request for optimistic locking.
+// When the version is stale (409 Conflict), it is refreshed and the action is retried once.
+func (s *SCM) withVersion(ctx context.Context, id int, action string, body any) (err error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		var pr pullRequest
+		if _, err := s.client.Do(ctx, http.MethodGet, s.prPath(id), nil, nil, &pr); err != nil {
+			return err
+		}
+
+		query := url.Values{"version": []string{strconv.Itoa(pr.Version)}}
+		if _, err = s.client.Do(ctx, http.MethodPost, s.prPath(id)+"/"+action, query, body, nil); err == nil {
+			return nil
+		}
+
+		var statusErr *rest.StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusConflict {
+			return err
+		}
+
+		slog.Debug("retrying pull request action", "action", action, "reason", "stale version")
+	}
+
+	return err
+}
+
+func (s *SCM) repoPath() string {
+	return "/projects/" + url.PathEscape(s.projectKey) + "/repos/" + url.PathEscape(s.repoSlug)
+}
+
+func (s *SCM) prPath(id int) string {
+	return s.repoPath() + "/pull-requests/" + strconv.Itoa(id)
+}
+
+type prs struct {
+	ctx  context.Context
+	scm  *SCM
+	base string
+
+	err error
+}
+
+func (s *SCM) listPRs(ctx context.Context, base string) *prs {
+	return &prs{ctx, s, base, nil}
+}
+
+func (p *prs) Err() error {
+	return p.err
+}
+
+func (p *prs) All() iter.Seq[*pullRequest] {
+	return iter.Seq[*pullRequest](func(yield func(*pullRequest) bool) {
+		query := url.Values{
+			"state": []string{"OPEN"},
+			"limit": []string{strconv.Itoa(pageLimit)},
+		}
+
+		if p.base != "" {
+			query.Set("at", "refs/heads/"+p.base)
+			query.Set("direction", "INCOMING")
+		}
+
+		for {
+			var page struct {
+				Values        []*pullRequest `json:"values"`
+				IsLastPage    bool           `json:"isLastPage"`
+				NextPageStart int            `json:"nextPageStart"`
+			}
+
+			if _, err := p.scm.client.Do(p.ctx, http.MethodGet, p.scm.repoPath()+"/pull-requests", query, nil, &page); err != nil {
+				p.err = err
+				return
+			}
+
+			for _, pr := range page.Values {
+				if !strings.HasPrefix(pr.FromRef.DisplayID, "glu/") {
+					continue
+				}
+
+				if !yield(pr) {
+					return
+				}
+			}
+
+			if page.IsLastPage {
+				return
+			}
+
+			query.Set("start", strconv.Itoa(page.NextPageStart))
+		}
+	})
+}
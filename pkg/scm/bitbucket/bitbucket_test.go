@@ -0,0 +1,258 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/get-glu/glu/pkg/scm/internal/scmtest"
+	"github.com/get-glu/glu/pkg/src/git"
+)
+
+const repoPath = "/rest/api/1.0/projects/PRJ/repos/repo"
+
+func newServer(t *testing.T) (*scmtest.Server, *SCM) {
+	t.Helper()
+
+	srv := scmtest.NewServer(t)
+
+	return srv, New(srv.Client(), srv.URL()+"/rest/api/1.0", "PRJ", "repo")
+}
+
+func pr(id int, from, to string) map[string]any {
+	return map[string]any{
+		"id":          id,
+		"version":     1,
+		"title":       "Update " + to,
+		"description": "body",
+		"fromRef":     map[string]any{"id": "refs/heads/" + from, "displayId": from},
+		// the target ref reports the current head of the base branch
+		"toRef": map[string]any{"id": "refs/heads/" + to, "displayId": to, "latestCommit": "head"},
+		"links": map[string]any{"self": []map[string]any{{"href": "https://bitbucket.example.com/pr/" + strconv.Itoa(id)}}},
+	}
+}
+
+func TestListProposals(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.HandleFunc("GET "+repoPath+"/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		page := map[string]any{"isLastPage": true}
+		switch r.URL.Query().Get("start") {
+		case "":
+			page = map[string]any{
+				"isLastPage":    false,
+				"nextPageStart": 2,
+				"values": []map[string]any{
+					pr(1, "glu/production/aaa", "main"),
+					pr(2, "feature/unrelated", "main"),
+				},
+			}
+		case "2":
+			page["values"] = []map[string]any{
+				pr(3, "glu/staging/bbb", "main"),
+				pr(4, "glu/productionfoo/ccc", "main"),
+			}
+		default:
+			t.Errorf("unexpected start %q", r.URL.Query().Get("start"))
+		}
+
+		_ = json.NewEncoder(w).Encode(page)
+	})
+	srv.Handle("GET "+repoPath+"/pull-requests/1/merge-base", http.StatusOK, map[string]any{"id": "base"})
+
+	proposals, err := scm.ListProposals(context.Background(), "glu/production")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*git.Proposal{{
+		Number:       1,
+		URL:          "https://bitbucket.example.com/pr/1",
+		BaseRevision: "base",
+		BaseBranch:   "main",
+		Branch:       "glu/production/aaa",
+		Digest:       "aaa",
+		Title:        "Update main",
+		Body:         "body",
+		ExternalMetadata: map[string]any{
+			BitbucketPRIDField: 1,
+		},
+	}}
+
+	if !reflect.DeepEqual(proposals, expected) {
+		t.Errorf("unexpected proposals\nexpected: %+v\nfound:    %+v", expected, proposals)
+	}
+
+	pages := srv.Find(http.MethodGet, repoPath+"/pull-requests")
+	if len(pages) != 2 {
+		t.Fatalf("expected two pages to be requested, found %d", len(pages))
+	}
+
+	for i, expected := range []string{"limit=100&state=OPEN", "limit=100&start=2&state=OPEN"} {
+		if pages[i].Query != expected {
+			t.Errorf("page %d: expected query %q, found %q", i+1, expected, pages[i].Query)
+		}
+	}
+}
+
+func TestGetCurrentProposal_BaseRevision(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("GET "+repoPath+"/pull-requests", http.StatusOK, map[string]any{
+		"isLastPage": true,
+		"values": []map[string]any{
+			// a sibling phase which shares the prefix of the requested phase
+			pr(4, "glu/production-eu/def", "main"),
+			pr(3, "glu/production/abc", "main"),
+		},
+	})
+	srv.Handle("GET "+repoPath+"/pull-requests/3/merge-base", http.StatusOK, map[string]any{"id": "base"})
+
+	proposal, err := scm.GetCurrentProposal(context.Background(), "main", "glu/production")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proposal.Number != 3 || proposal.BaseRevision != "base" {
+		t.Errorf("unexpected proposal %+v", proposal)
+	}
+
+	list := srv.Find(http.MethodGet, repoPath+"/pull-requests")
+	if len(list) != 1 || list[0].Query != "at=refs%2Fheads%2Fmain&direction=INCOMING&limit=100&state=OPEN" {
+		t.Errorf("unexpected list requests %+v", list)
+	}
+
+	if _, err := scm.GetCurrentProposal(context.Background(), "main", "glu/development"); !errors.Is(err, git.ErrProposalNotFound) {
+		t.Errorf("expected proposal not found, found %v", err)
+	}
+}
+
+func TestCreateProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("POST "+repoPath+"/pull-requests", http.StatusCreated, pr(9, "glu/production/abc", "main"))
+
+	proposal := &git.Proposal{
+		BaseBranch: "main",
+		Branch:     "glu/production/abc",
+		Title:      "Update production",
+		Body:       "body",
+	}
+
+	if err := scm.CreateProposal(context.Background(), proposal, git.ProposalOption{
+		Labels:    []string{"glu"},
+		Reviewers: []string{"octocat"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if proposal.Number != 9 || proposal.URL != "https://bitbucket.example.com/pr/9" || proposal.ExternalMetadata[BitbucketPRIDField] != 9 {
+		t.Errorf("unexpected proposal %+v", proposal)
+	}
+
+	created := srv.Find(http.MethodPost, repoPath+"/pull-requests")
+	if len(created) != 1 {
+		t.Fatalf("expected one create request, found %d", len(created))
+	}
+
+	repo := map[string]any{"slug": "repo", "project": map[string]any{"key": "PRJ"}}
+	expected := map[string]any{
+		"title":       "Update production",
+		"description": "body",
+		"fromRef":     map[string]any{"id": "refs/heads/glu/production/abc", "repository": repo},
+		"toRef":       map[string]any{"id": "refs/heads/main", "repository": repo},
+		"reviewers":   []any{map[string]any{"user": map[string]any{"name": "octocat"}}},
+	}
+
+	if !reflect.DeepEqual(created[0].Body, expected) {
+		t.Errorf("unexpected create body\nexpected: %v\nfound:    %v", expected, created[0].Body)
+	}
+}
+
+func TestMergeProposal(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		method   git.MergeMethod
+		strategy any
+	}{
+		// the repositories default strategy is used when no method is configured
+		{name: "default"},
+		{name: "merge", method: git.MergeMethodMerge, strategy: "no-ff"},
+		{name: "squash", method: git.MergeMethodSquash, strategy: "squash"},
+		{name: "rebase", method: git.MergeMethodRebase, strategy: "rebase-no-ff"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			srv, scm := newServer(t)
+			srv.Handle("GET "+repoPath+"/pull-requests/9", http.StatusOK, map[string]any{"id": 9, "version": 4})
+			srv.Handle("POST "+repoPath+"/pull-requests/9/merge", http.StatusOK, pr(9, "glu/production/abc", "main"))
+
+			if err := scm.MergeProposal(context.Background(), &git.Proposal{
+				ExternalMetadata: map[string]any{BitbucketPRIDField: 9},
+			}, git.MergeOption{Method: test.method}); err != nil {
+				t.Fatal(err)
+			}
+
+			merges := srv.Find(http.MethodPost, repoPath+"/pull-requests/9/merge")
+			if len(merges) != 1 || merges[0].Query != "version=4" {
+				t.Fatalf("unexpected merge requests %+v", merges)
+			}
+
+			if strategy := merges[0].Body["strategyId"]; strategy != test.strategy {
+				t.Errorf("expected strategy %v, found %v", test.strategy, strategy)
+			}
+		})
+	}
+}
+
+func TestMergeProposal_StaleVersion(t *testing.T) {
+	srv, scm := newServer(t)
+
+	var version int
+	srv.HandleFunc("GET "+repoPath+"/pull-requests/9", func(w http.ResponseWriter, r *http.Request) {
+		version++
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 9, "version": version})
+	})
+	srv.HandleFunc("POST "+repoPath+"/pull-requests/9/merge", func(w http.ResponseWriter, r *http.Request) {
+		// only the refreshed version is accepted
+		if r.URL.Query().Get("version") != "2" {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(pr(9, "glu/production/abc", "main"))
+	})
+
+	if err := scm.MergeProposal(context.Background(), &git.Proposal{
+		ExternalMetadata: map[string]any{BitbucketPRIDField: 9},
+	}, git.MergeOption{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if merges := srv.Find(http.MethodPost, repoPath+"/pull-requests/9/merge"); len(merges) != 2 {
+		t.Errorf("expected the merge to be retried once, found %d attempts", len(merges))
+	}
+}
+
+func TestCloseProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("POST "+repoPath+"/pull-requests/9/comments", http.StatusCreated, map[string]any{"id": 1})
+	srv.Handle("GET "+repoPath+"/pull-requests/9", http.StatusOK, map[string]any{"id": 9, "version": 4})
+	srv.Handle("POST "+repoPath+"/pull-requests/9/decline", http.StatusOK, pr(9, "glu/production/abc", "main"))
+
+	if err := scm.CloseProposal(context.Background(), &git.Proposal{
+		ExternalMetadata: map[string]any{BitbucketPRIDField: 9},
+	}, git.CloseOption{Comment: "superseded"}); err != nil {
+		t.Fatal(err)
+	}
+
+	comments := srv.Find(http.MethodPost, repoPath+"/pull-requests/9/comments")
+	if len(comments) != 1 || comments[0].Body["text"] != "superseded" {
+		t.Errorf("unexpected comments %+v", comments)
+	}
+
+	declines := srv.Find(http.MethodPost, repoPath+"/pull-requests/9/decline")
+	if len(declines) != 1 || declines[0].Query != "version=4" {
+		t.Errorf("unexpected declines %+v", declines)
+	}
+}
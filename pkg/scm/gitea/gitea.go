@@ -0,0 +1,304 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/get-glu/glu/pkg/scm/internal/rest"
+	"github.com/get-glu/glu/pkg/src/git"
+)
+
+const (
+	GiteaPRNumberField = "gitea.pr.number"
+
+	pageLimit = 50
+)
+
+var _ git.Proposer = (*SCM)(nil)
+
+// SCM is a git.Proposer which manages proposals as Gitea (or Forgejo) pull requests.
+type SCM struct {
+	client    *rest.Client
+	repoOwner string
+	repoName  string
+}
+
+// New constructs a Gitea proposer for the identified repository.
+// The apiURL is the base of the Gitea REST API (e.g. https://gitea.example.com/api/v1)
+// and the provided client is expected to authenticate requests (e.g. with an access token).
+func New(client *http.Client, apiURL, repoOwner, repoName string) *SCM {
+	return &SCM{client: rest.New(client, apiURL), repoOwner: repoOwner, repoName: repoName}
+}
+
+type branchRef struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+type pullRequest struct {
+	Number  int       `json:"number"`
+	Title   string    `json:"title"`
+	Body    string    `json:"body"`
+	HTMLURL string    `json:"html_url"`
+	Head    branchRef `json:"head"`
+	Base    branchRef `json:"base"`
+}
+
+func (s *SCM) GetCurrentProposal(ctx context.Context, baseBranch, branchPrefix string) (*git.Proposal, error) {
+	var (
+		prs      = s.listPRs(ctx, baseBranch)
+		proposal *git.Proposal
+	)
+
+	for pr := range prs.All() {
+		if strings.HasPrefix(pr.Head.Ref, branchPrefix+"/") {
+			proposal = toProposal(pr)
+			break
+		}
+	}
+
+	if err := prs.Err(); err != nil {
+		return nil, err
+	}
+
+	if proposal == nil {
+		return nil, fmt.Errorf("base %q: prefix %q: %w", baseBranch, branchPrefix, git.ErrProposalNotFound)
+	}
+
+	return proposal, nil
+}
+
+func (s *SCM) ListProposals(ctx context.Context, branchPrefix string) (proposals []*git.Proposal, err error) {
+	prs := s.listPRs(ctx, "")
+	for pr := range prs.All() {
+		if strings.HasPrefix(pr.Head.Ref, branchPrefix+"/") {
+			proposals = append(proposals, toProposal(pr))
+		}
+	}
+
+	if err := prs.Err(); err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
+func toProposal(pr *pullRequest) *git.Proposal {
+	parts := strings.Split(pr.Head.Ref, "/")
+	return &git.Proposal{
+		Number:       pr.Number,
+		URL:          pr.HTMLURL,
+		BaseRevision: pr.Base.SHA,
+		BaseBranch:   pr.Base.Ref,
+		Branch:       pr.Head.Ref,
+		Digest:       parts[len(parts)-1],
+		Title:        pr.Title,
+		Body:         pr.Body,
+		ExternalMetadata: map[string]any{
+			GiteaPRNumberField: pr.Number,
+		},
+	}
+}
+
+type createPullRequest struct {
+	Head   string  `json:"head"`
+	Base   string  `json:"base"`
+	Title  string  `json:"title"`
+	Body   string  `json:"body"`
+	Labels []int64 `json:"labels,omitempty"`
+}
+
+func (s *SCM) CreateProposal(ctx context.Context, proposal *git.Proposal, opts git.ProposalOption) error {
+	labels, err := s.labelIDs(ctx, opts.Labels)
+	if err != nil {
+		return err
+	}
+
+	var pr pullRequest
+	if _, err := s.client.Do(ctx, http.MethodPost, s.repoPath()+"/pulls", nil, createPullRequest{
+		Head:   proposal.Branch,
+		Base:   proposal.BaseBranch,
+		Title:  proposal.Title,
+		Body:   proposal.Body,
+		Labels: labels,
+	}, &pr); err != nil {
+		return err
+	}
+
+	slog.Info("proposal created", "scm_type", "gitea", "proposal_url", pr.HTMLURL)
+
+	proposal.Number = pr.Number
+	proposal.URL = pr.HTMLURL
+	proposal.ExternalMetadata = map[string]any{
+		GiteaPRNumberField: pr.Number,
+	}
+
+	if len(opts.Reviewers) > 0 {
+		if _, err := s.client.Do(ctx, http.MethodPost, s.prPath(pr.Number)+"/requested_reviewers", nil, map[string]any{
+			"reviewers": opts.Reviewers,
+		}, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SCM) MergeProposal(ctx context.Context, proposal *git.Proposal, opts git.MergeOption) error {
+	number, ok := proposal.ExternalMetadata[GiteaPRNumberField].(int)
+	if !ok {
+		slog.Warn("could not merge pr", "reason", "missing PR number on proposal")
+		return nil
+	}
+
+	method := opts.Method
+	if method == "" {
+		method = git.MergeMethodMerge
+	}
+
+	_, err := s.client.Do(ctx, http.MethodPost, s.prPath(number)+"/merge", nil, map[string]any{
+		"Do":                        method,
+		"delete_branch_after_merge": true,
+	}, nil)
+
+	return err
+}
+
+func (s *SCM) CloseProposal(ctx context.Context, proposal *git.Proposal, opts git.CloseOption) error {
+	number, ok := proposal.ExternalMetadata[GiteaPRNumberField].(int)
+	if !ok {
+		slog.Warn("could not close pr", "reason", "missing PR number on proposal")
+		return nil
+	}
+
+	if opts.Comment != "" {
+		// pull request comments are managed through the issues API
+		if _, err := s.client.Do(ctx, http.MethodPost, s.repoPath()+"/issues/"+strconv.Itoa(number)+"/comments", nil, map[string]any{
+			"body": opts.Comment,
+		}, nil); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.client.Do(ctx, http.MethodPatch, s.prPath(number), nil, map[string]any{
+		"state": "closed",
+	}, nil)
+
+	return err
+}
+
+// labelIDs resolves the provided label names to the repositories label IDs
+// as Gitea only accepts IDs when creating pull requests.
+func (s *SCM) labelIDs(ctx context.Context, names []string) ([]int64, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var (
+		ids   = map[string]int64{}
+		query = url.Values{"limit": []string{strconv.Itoa(pageLimit)}}
+	)
+
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var labels []struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		}
+
+		resp, err := s.client.Do(ctx, http.MethodGet, s.repoPath()+"/labels", query, nil, &labels)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, label := range labels {
+			ids[label.Name] = label.ID
+		}
+
+		if !hasNextPage(resp) {
+			break
+		}
+	}
+
+	result := make([]int64, 0, len(names))
+	for _, name := range names {
+		id, ok := ids[name]
+		if !ok {
+			return nil, fmt.Errorf("label %q: not found", name)
+		}
+
+		result = append(result, id)
+	}
+
+	return result, nil
+}
+
+func (s *SCM) repoPath() string {
+	return "/repos/" + url.PathEscape(s.repoOwner) + "/" + url.PathEscape(s.repoName)
+}
+
+func (s *SCM) prPath(number int) string {
+	return s.repoPath() + "/pulls/" + strconv.Itoa(number)
+}
+
+// hasNextPage returns true if the responses Link header contains a next page.
+func hasNextPage(resp *http.Response) bool {
+	return strings.Contains(resp.Header.Get("Link"), `rel="next"`)
+}
+
+type prs struct {
+	ctx  context.Context
+	scm  *SCM
+	base string
+
+	err error
+}
+
+func (s *SCM) listPRs(ctx context.Context, base string) *prs {
+	return &prs{ctx, s, base, nil}
+}
+
+func (p *prs) Err() error {
+	return p.err
+}
+
+func (p *prs) All() iter.Seq[*pullRequest] {
+	return iter.Seq[*pullRequest](func(yield func(*pullRequest) bool) {
+		query := url.Values{
+			"state": []string{"open"},
+			"limit": []string{strconv.Itoa(pageLimit)},
+		}
+
+		for page := 1; ; page++ {
+			query.Set("page", strconv.Itoa(page))
+
+			var prs []*pullRequest
+			resp, err := p.scm.client.Do(p.ctx, http.MethodGet, p.scm.repoPath()+"/pulls", query, nil, &prs)
+			if err != nil {
+				p.err = err
+				return
+			}
+
+			for _, pr := range prs {
+				if (p.base != "" && pr.Base.Ref != p.base) || !strings.HasPrefix(pr.Head.Ref, "glu/") {
+					continue
+				}
+
+				if !yield(pr) {
+					return
+				}
+			}
+
+			if !hasNextPage(resp) {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,250 @@
+package gitea
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/get-glu/glu/pkg/scm/internal/scmtest"
+	"github.com/get-glu/glu/pkg/src/git"
+)
+
+const repoPath = "/api/v1/repos/owner/name"
+
+func newServer(t *testing.T) (*scmtest.Server, *SCM) {
+	t.Helper()
+
+	srv := scmtest.NewServer(t)
+
+	return srv, New(srv.Client(), srv.URL()+"/api/v1", "owner", "name")
+}
+
+func pr(number int, head, base string) map[string]any {
+	return map[string]any{
+		"number":   number,
+		"title":    "Update " + base,
+		"body":     "body",
+		"html_url": "https://gitea.example.com/pulls/" + strconv.Itoa(number),
+		"head":     map[string]any{"ref": head, "sha": "head-" + head},
+		"base":     map[string]any{"ref": base, "sha": "base-" + head},
+	}
+}
+
+func TestListProposals(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Paginate("GET "+repoPath+"/pulls",
+		[]map[string]any{
+			pr(1, "glu/production/aaa", "main"),
+			pr(2, "feature/unrelated", "main"),
+		},
+		[]map[string]any{
+			pr(3, "glu/staging/bbb", "main"),
+			pr(4, "glu/productionfoo/ccc", "main"),
+			pr(5, "glu/production/ddd", "release"),
+		},
+	)
+
+	proposals, err := scm.ListProposals(context.Background(), "glu/production")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*git.Proposal{
+		{
+			Number:       1,
+			URL:          "https://gitea.example.com/pulls/1",
+			BaseRevision: "base-glu/production/aaa",
+			BaseBranch:   "main",
+			Branch:       "glu/production/aaa",
+			Digest:       "aaa",
+			Title:        "Update main",
+			Body:         "body",
+			ExternalMetadata: map[string]any{
+				GiteaPRNumberField: 1,
+			},
+		},
+		{
+			Number:       5,
+			URL:          "https://gitea.example.com/pulls/5",
+			BaseRevision: "base-glu/production/ddd",
+			BaseBranch:   "release",
+			Branch:       "glu/production/ddd",
+			Digest:       "ddd",
+			Title:        "Update release",
+			Body:         "body",
+			ExternalMetadata: map[string]any{
+				GiteaPRNumberField: 5,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(proposals, expected) {
+		t.Errorf("unexpected proposals\nexpected: %+v\nfound:    %+v", expected, proposals)
+	}
+
+	pages := srv.Find(http.MethodGet, repoPath+"/pulls")
+	if len(pages) != 2 {
+		t.Fatalf("expected two pages to be requested, found %d", len(pages))
+	}
+
+	for i, page := range pages {
+		if expected := fmt.Sprintf("limit=%d&page=%d&state=open", pageLimit, i+1); page.Query != expected {
+			t.Errorf("page %d: expected query %q, found %q", i+1, expected, page.Query)
+		}
+	}
+}
+
+func TestGetCurrentProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Paginate("GET "+repoPath+"/pulls",
+		[]map[string]any{
+			pr(1, "glu/production/aaa", "release"),
+			pr(2, "glu/staging/bbb", "main"),
+		},
+		[]map[string]any{
+			// a sibling phase which shares the prefix of the requested phase
+			pr(4, "glu/production-eu/ddd", "main"),
+			pr(3, "glu/production/ccc", "main"),
+		},
+	)
+
+	proposal, err := scm.GetCurrentProposal(context.Background(), "main", "glu/production")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first page contains a matching branch for a different base
+	if proposal.Number != 3 || proposal.BaseBranch != "main" || proposal.Digest != "ccc" {
+		t.Errorf("unexpected proposal %+v", proposal)
+	}
+
+	if _, err := scm.GetCurrentProposal(context.Background(), "main", "glu/development"); !errors.Is(err, git.ErrProposalNotFound) {
+		t.Errorf("expected proposal not found, found %v", err)
+	}
+}
+
+func TestListProposals_Error(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("GET "+repoPath+"/pulls", http.StatusForbidden, map[string]any{"message": "forbidden"})
+
+	if _, err := scm.ListProposals(context.Background(), "glu/production"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCreateProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Paginate("GET "+repoPath+"/labels",
+		[]map[string]any{{"id": 1, "name": "glu"}},
+		[]map[string]any{{"id": 2, "name": "production"}, {"id": 3, "name": "staging"}},
+	)
+	srv.Handle("POST "+repoPath+"/pulls", http.StatusCreated, pr(9, "glu/production/abc", "main"))
+	srv.Handle("POST "+repoPath+"/pulls/9/requested_reviewers", http.StatusCreated, nil)
+
+	proposal := &git.Proposal{
+		BaseBranch: "main",
+		Branch:     "glu/production/abc",
+		Title:      "Update production",
+		Body:       "body",
+	}
+
+	if err := scm.CreateProposal(context.Background(), proposal, git.ProposalOption{
+		Labels:    []string{"glu", "production"},
+		Reviewers: []string{"octocat"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if proposal.Number != 9 || proposal.ExternalMetadata[GiteaPRNumberField] != 9 {
+		t.Errorf("unexpected proposal %+v", proposal)
+	}
+
+	created := srv.Find(http.MethodPost, repoPath+"/pulls")
+	if len(created) != 1 {
+		t.Fatalf("expected one create request, found %d", len(created))
+	}
+
+	expected := map[string]any{
+		"head":   "glu/production/abc",
+		"base":   "main",
+		"title":  "Update production",
+		"body":   "body",
+		"labels": []any{float64(1), float64(2)},
+	}
+
+	if !reflect.DeepEqual(created[0].Body, expected) {
+		t.Errorf("unexpected create body\nexpected: %v\nfound:    %v", expected, created[0].Body)
+	}
+
+	reviewers := srv.Find(http.MethodPost, repoPath+"/pulls/9/requested_reviewers")
+	if len(reviewers) != 1 || !reflect.DeepEqual(reviewers[0].Body["reviewers"], []any{"octocat"}) {
+		t.Errorf("unexpected reviewer requests %+v", reviewers)
+	}
+}
+
+func TestCreateProposal_UnknownLabel(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Paginate("GET "+repoPath+"/labels", []map[string]any{{"id": 1, "name": "glu"}})
+
+	if err := scm.CreateProposal(context.Background(), &git.Proposal{}, git.ProposalOption{
+		Labels: []string{"missing"},
+	}); err == nil {
+		t.Fatal("expected error for unknown label")
+	}
+
+	if created := srv.Find(http.MethodPost, repoPath+"/pulls"); len(created) > 0 {
+		t.Error("expected no pull request to be created")
+	}
+}
+
+func TestMergeProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("POST "+repoPath+"/pulls/9/merge", http.StatusOK, nil)
+
+	proposal := &git.Proposal{ExternalMetadata: map[string]any{GiteaPRNumberField: 9}}
+	if err := scm.MergeProposal(context.Background(), proposal, git.MergeOption{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := scm.MergeProposal(context.Background(), proposal, git.MergeOption{Method: git.MergeMethodSquash}); err != nil {
+		t.Fatal(err)
+	}
+
+	merges := srv.Find(http.MethodPost, repoPath+"/pulls/9/merge")
+	if len(merges) != 2 {
+		t.Fatalf("expected two merge requests, found %d", len(merges))
+	}
+
+	for i, method := range []git.MergeMethod{git.MergeMethodMerge, git.MergeMethodSquash} {
+		expected := map[string]any{"Do": string(method), "delete_branch_after_merge": true}
+		if !reflect.DeepEqual(merges[i].Body, expected) {
+			t.Errorf("unexpected merge body\nexpected: %v\nfound:    %v", expected, merges[i].Body)
+		}
+	}
+}
+
+func TestCloseProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("POST "+repoPath+"/issues/9/comments", http.StatusCreated, map[string]any{"id": 1})
+	srv.Handle("PATCH "+repoPath+"/pulls/9", http.StatusCreated, pr(9, "glu/production/abc", "main"))
+
+	if err := scm.CloseProposal(context.Background(), &git.Proposal{
+		ExternalMetadata: map[string]any{GiteaPRNumberField: 9},
+	}, git.CloseOption{Comment: "superseded"}); err != nil {
+		t.Fatal(err)
+	}
+
+	comments := srv.Find(http.MethodPost, repoPath+"/issues/9/comments")
+	if len(comments) != 1 || comments[0].Body["body"] != "superseded" {
+		t.Errorf("unexpected comments %+v", comments)
+	}
+
+	updates := srv.Find(http.MethodPatch, repoPath+"/pulls/9")
+	if len(updates) != 1 || updates[0].Body["state"] != "closed" {
+		t.Errorf("unexpected updates %+v", updates)
+	}
+}
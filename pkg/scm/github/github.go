@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"log/slog"
@@ -15,7 +16,10 @@ const (
 	GitHubPRNumberField = "github.pr.number"
 )
 
-var _ git.Proposer = (*SCM)(nil)
+var (
+	_ git.Proposer             = (*SCM)(nil)
+	_ git.ProposalStatusSource = (*SCM)(nil)
+)
 
 type SCM struct {
 	client    *github.Client
@@ -34,17 +38,8 @@ func (s *SCM) GetCurrentProposal(ctx context.Context, baseBranch, branchPrefix s
 	)
 
 	for pr := range prs.All() {
-		parts := strings.Split(pr.Head.GetRef(), "/")
-		if strings.HasPrefix(pr.Head.GetRef(), branchPrefix) {
-			proposal = &git.Proposal{
-				BaseRevision: pr.Base.GetSHA(),
-				BaseBranch:   pr.Base.GetRef(),
-				Branch:       pr.Head.GetRef(),
-				Digest:       parts[len(parts)-1],
-				ExternalMetadata: map[string]any{
-					GitHubPRNumberField: pr.GetNumber(),
-				},
-			}
+		if strings.HasPrefix(pr.Head.GetRef(), branchPrefix+"/") {
+			proposal = toProposal(pr)
 			break
 		}
 	}
@@ -60,6 +55,36 @@ func (s *SCM) GetCurrentProposal(ctx context.Context, baseBranch, branchPrefix s
 	return proposal, nil
 }
 
+func (s *SCM) ListProposals(ctx context.Context, branchPrefix string) (proposals []*git.Proposal, err error) {
+	prs := s.listPRs(ctx, "")
+	for pr := range prs.All() {
+		if strings.HasPrefix(pr.Head.GetRef(), branchPrefix+"/") {
+			proposals = append(proposals, toProposal(pr))
+		}
+	}
+
+	if err := prs.Err(); err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
+func toProposal(pr *github.PullRequest) *git.Proposal {
+	parts := strings.Split(pr.Head.GetRef(), "/")
+	return &git.Proposal{
+		Number:       pr.GetNumber(),
+		URL:          pr.GetHTMLURL(),
+		BaseRevision: pr.Base.GetSHA(),
+		BaseBranch:   pr.Base.GetRef(),
+		Branch:       pr.Head.GetRef(),
+		Digest:       parts[len(parts)-1],
+		ExternalMetadata: map[string]any{
+			GitHubPRNumberField: pr.GetNumber(),
+		},
+	}
+}
+
 func (s *SCM) CreateProposal(ctx context.Context, proposal *git.Proposal, opts git.ProposalOption) error {
 	pr, _, err := s.client.PullRequests.Create(ctx, s.repoOwner, s.repoName, &github.NewPullRequest{
 		Base:  github.String(proposal.BaseBranch),
@@ -73,6 +98,8 @@ func (s *SCM) CreateProposal(ctx context.Context, proposal *git.Proposal, opts g
 
 	slog.Info("proposal created", "scm_type", "github", "proposal_url", pr.GetHTMLURL())
 
+	proposal.Number = pr.GetNumber()
+	proposal.URL = pr.GetHTMLURL()
 	proposal.ExternalMetadata = map[string]any{
 		GitHubPRNumberField: pr.GetNumber(),
 	}
@@ -83,30 +110,160 @@ func (s *SCM) CreateProposal(ctx context.Context, proposal *git.Proposal, opts g
 		}
 	}
 
+	if len(opts.Reviewers) > 0 {
+		if _, _, err := s.client.PullRequests.RequestReviewers(ctx, s.repoOwner, s.repoName, pr.GetNumber(), github.ReviewersRequest{
+			Reviewers: opts.Reviewers,
+		}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (s *SCM) MergeProposal(ctx context.Context, proposal *git.Proposal) error {
+func (s *SCM) MergeProposal(ctx context.Context, proposal *git.Proposal, opts git.MergeOption) error {
 	number, ok := proposal.ExternalMetadata[GitHubPRNumberField].(int)
 	if !ok {
-		slog.Warn("could not close pr", "reason", "missing PR number on proposal")
+		slog.Warn("could not merge pr", "reason", "missing PR number on proposal")
 		return nil
 	}
 
+	method := string(opts.Method)
+	if method == "" {
+		method = string(git.MergeMethodMerge)
+	}
+
 	_, _, err := s.client.PullRequests.Merge(ctx, s.repoOwner, s.repoName, number, "", &github.PullRequestOptions{
-		MergeMethod: "merge",
+		MergeMethod: method,
 	})
 
 	return err
 }
 
-func (s *SCM) CloseProposal(ctx context.Context, proposal *git.Proposal) error {
+// ProposalStatus returns the checks, approvals and mergeability of the proposals pull request.
+// Checks are collected from both the checks API and commit statuses on the head commit.
+func (s *SCM) ProposalStatus(ctx context.Context, proposal *git.Proposal) (*git.ProposalStatus, error) {
+	number, ok := proposal.ExternalMetadata[GitHubPRNumberField].(int)
+	if !ok {
+		return nil, errors.New("missing PR number on proposal")
+	}
+
+	pr, _, err := s.client.PullRequests.Get(ctx, s.repoOwner, s.repoName, number)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &git.ProposalStatus{
+		Checks:     map[string]git.CheckState{},
+		Conflicted: pr.GetMergeableState() == "dirty",
+	}
+
+	sha := pr.GetHead().GetSHA()
+
+	checkOpts := &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		runs, resp, err := s.client.Checks.ListCheckRunsForRef(ctx, s.repoOwner, s.repoName, sha, checkOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, run := range runs.CheckRuns {
+			state := git.CheckStatePending
+			if run.GetStatus() == "completed" {
+				switch run.GetConclusion() {
+				case "success", "neutral", "skipped":
+					state = git.CheckStateSuccess
+				default:
+					state = git.CheckStateFailure
+				}
+			}
+
+			status.Checks[run.GetName()] = state
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		checkOpts.Page = resp.NextPage
+	}
+
+	statusOpts := &github.ListOptions{PerPage: 100}
+	for {
+		combined, resp, err := s.client.Repositories.GetCombinedStatus(ctx, s.repoOwner, s.repoName, sha, statusOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, st := range combined.Statuses {
+			state := git.CheckStatePending
+			switch st.GetState() {
+			case "success":
+				state = git.CheckStateSuccess
+			case "failure", "error":
+				state = git.CheckStateFailure
+			}
+
+			status.Checks[st.GetContext()] = state
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		statusOpts.Page = resp.NextPage
+	}
+
+	// only the latest review from each reviewer counts towards approvals
+	var (
+		latest     = map[string]string{}
+		reviewOpts = &github.ListOptions{PerPage: 100}
+	)
+
+	for {
+		reviews, resp, err := s.client.PullRequests.ListReviews(ctx, s.repoOwner, s.repoName, number, reviewOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, review := range reviews {
+			switch review.GetState() {
+			case "APPROVED", "CHANGES_REQUESTED", "DISMISSED":
+				latest[review.GetUser().GetLogin()] = review.GetState()
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		reviewOpts.Page = resp.NextPage
+	}
+
+	for _, state := range latest {
+		if state == "APPROVED" {
+			status.Approvals++
+		}
+	}
+
+	return status, nil
+}
+
+func (s *SCM) CloseProposal(ctx context.Context, proposal *git.Proposal, opts git.CloseOption) error {
 	number, ok := proposal.ExternalMetadata[GitHubPRNumberField].(int)
 	if !ok {
 		slog.Warn("could not close pr", "reason", "missing PR number on proposal")
 		return nil
 	}
 
+	if opts.Comment != "" {
+		if _, _, err := s.client.Issues.CreateComment(ctx, s.repoOwner, s.repoName, number, &github.IssueComment{
+			Body: github.String(opts.Comment),
+		}); err != nil {
+			return err
+		}
+	}
+
 	_, _, err := s.client.PullRequests.Edit(ctx, s.repoOwner, s.repoName, number, &github.PullRequest{
 		State: github.String("closed"),
 	})
@@ -0,0 +1,275 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/get-glu/glu/pkg/scm/internal/scmtest"
+	"github.com/get-glu/glu/pkg/src/git"
+	"github.com/google/go-github/v64/github"
+)
+
+const repoPath = "/repos/owner/name"
+
+func newServer(t *testing.T) (*scmtest.Server, *SCM) {
+	t.Helper()
+
+	srv := scmtest.NewServer(t)
+
+	client := github.NewClient(srv.Client())
+	baseURL, err := url.Parse(srv.URL() + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client.BaseURL = baseURL
+
+	return srv, New(client, "owner", "name")
+}
+
+func pr(number int, head, base string) map[string]any {
+	return map[string]any{
+		"number":   number,
+		"html_url": "https://github.example.com/pull/" + strconv.Itoa(number),
+		"head":     map[string]any{"ref": head, "sha": "head-" + head},
+		"base":     map[string]any{"ref": base, "sha": "base-" + head},
+	}
+}
+
+func TestListProposals(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Paginate("GET "+repoPath+"/pulls",
+		[]map[string]any{
+			pr(1, "glu/production/aaa", "main"),
+			pr(2, "feature/unrelated", "main"),
+		},
+		[]map[string]any{
+			pr(3, "glu/staging/bbb", "main"),
+			pr(4, "glu/productionfoo/ccc", "main"),
+			pr(5, "glu/production/ddd", "release"),
+		},
+	)
+
+	proposals, err := scm.ListProposals(context.Background(), "glu/production")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*git.Proposal{
+		{
+			Number:       1,
+			URL:          "https://github.example.com/pull/1",
+			BaseRevision: "base-glu/production/aaa",
+			BaseBranch:   "main",
+			Branch:       "glu/production/aaa",
+			Digest:       "aaa",
+			ExternalMetadata: map[string]any{
+				GitHubPRNumberField: 1,
+			},
+		},
+		{
+			Number:       5,
+			URL:          "https://github.example.com/pull/5",
+			BaseRevision: "base-glu/production/ddd",
+			BaseBranch:   "release",
+			Branch:       "glu/production/ddd",
+			Digest:       "ddd",
+			ExternalMetadata: map[string]any{
+				GitHubPRNumberField: 5,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(proposals, expected) {
+		t.Errorf("unexpected proposals\nexpected: %+v\nfound:    %+v", expected, proposals)
+	}
+
+	pages := srv.Find(http.MethodGet, repoPath+"/pulls")
+	if len(pages) != 2 {
+		t.Fatalf("expected two pages to be requested, found %d", len(pages))
+	}
+
+	for i, expected := range []string{"per_page=100&state=open", "page=2&per_page=100&state=open"} {
+		if pages[i].Query != expected {
+			t.Errorf("page %d: expected query %q, found %q", i+1, expected, pages[i].Query)
+		}
+	}
+}
+
+func TestGetCurrentProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("GET "+repoPath+"/pulls", http.StatusOK, []map[string]any{
+		pr(1, "glu/staging/aaa", "main"),
+		// a sibling phase which shares the prefix of the requested phase
+		pr(3, "glu/production-eu/ccc", "main"),
+		pr(2, "glu/production/bbb", "main"),
+	})
+
+	proposal, err := scm.GetCurrentProposal(context.Background(), "main", "glu/production")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proposal.Number != 2 || proposal.BaseRevision != "base-glu/production/bbb" || proposal.Digest != "bbb" {
+		t.Errorf("unexpected proposal %+v", proposal)
+	}
+
+	list := srv.Find(http.MethodGet, repoPath+"/pulls")
+	if len(list) != 1 || list[0].Query != "base=main&per_page=100&state=open" {
+		t.Errorf("unexpected list requests %+v", list)
+	}
+
+	if _, err := scm.GetCurrentProposal(context.Background(), "main", "glu/development"); !errors.Is(err, git.ErrProposalNotFound) {
+		t.Errorf("expected proposal not found, found %v", err)
+	}
+}
+
+func TestCreateProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("POST "+repoPath+"/pulls", http.StatusCreated, pr(9, "glu/production/abc", "main"))
+	srv.Handle("POST "+repoPath+"/issues/9/labels", http.StatusOK, []map[string]any{{"name": "glu"}})
+	srv.Handle("POST "+repoPath+"/pulls/9/requested_reviewers", http.StatusCreated, pr(9, "glu/production/abc", "main"))
+
+	proposal := &git.Proposal{
+		BaseBranch: "main",
+		Branch:     "glu/production/abc",
+		Title:      "Update production",
+		Body:       "body",
+	}
+
+	if err := scm.CreateProposal(context.Background(), proposal, git.ProposalOption{
+		Labels:    []string{"glu", "production"},
+		Reviewers: []string{"octocat"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if proposal.Number != 9 || proposal.URL != "https://github.example.com/pull/9" || proposal.ExternalMetadata[GitHubPRNumberField] != 9 {
+		t.Errorf("unexpected proposal %+v", proposal)
+	}
+
+	created := srv.Find(http.MethodPost, repoPath+"/pulls")
+	if len(created) != 1 {
+		t.Fatalf("expected one create request, found %d", len(created))
+	}
+
+	expected := map[string]any{
+		"head":  "glu/production/abc",
+		"base":  "main",
+		"title": "Update production",
+		"body":  "body",
+	}
+
+	if !reflect.DeepEqual(created[0].Body, expected) {
+		t.Errorf("unexpected create body\nexpected: %v\nfound:    %v", expected, created[0].Body)
+	}
+
+	labels := srv.Find(http.MethodPost, repoPath+"/issues/9/labels")
+	if len(labels) != 1 || strings.TrimSpace(string(labels[0].Raw)) != `["glu","production"]` {
+		t.Errorf("unexpected label requests %+v", labels)
+	}
+
+	reviewers := srv.Find(http.MethodPost, repoPath+"/pulls/9/requested_reviewers")
+	if len(reviewers) != 1 || !reflect.DeepEqual(reviewers[0].Body["reviewers"], []any{"octocat"}) {
+		t.Errorf("unexpected reviewer requests %+v", reviewers)
+	}
+}
+
+func TestMergeProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("PUT "+repoPath+"/pulls/9/merge", http.StatusOK, map[string]any{"merged": true})
+
+	proposal := &git.Proposal{ExternalMetadata: map[string]any{GitHubPRNumberField: 9}}
+	for _, method := range []git.MergeMethod{"", git.MergeMethodRebase} {
+		if err := scm.MergeProposal(context.Background(), proposal, git.MergeOption{Method: method}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	merges := srv.Find(http.MethodPut, repoPath+"/pulls/9/merge")
+	if len(merges) != 2 {
+		t.Fatalf("expected two merge requests, found %d", len(merges))
+	}
+
+	for i, method := range []string{"merge", "rebase"} {
+		if found := merges[i].Body["merge_method"]; found != method {
+			t.Errorf("merge %d: expected method %q, found %v", i+1, method, found)
+		}
+	}
+}
+
+func TestCloseProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("POST "+repoPath+"/issues/9/comments", http.StatusCreated, map[string]any{"id": 1})
+	srv.Handle("PATCH "+repoPath+"/pulls/9", http.StatusOK, pr(9, "glu/production/abc", "main"))
+
+	if err := scm.CloseProposal(context.Background(), &git.Proposal{
+		ExternalMetadata: map[string]any{GitHubPRNumberField: 9},
+	}, git.CloseOption{Comment: "superseded"}); err != nil {
+		t.Fatal(err)
+	}
+
+	comments := srv.Find(http.MethodPost, repoPath+"/issues/9/comments")
+	if len(comments) != 1 || comments[0].Body["body"] != "superseded" {
+		t.Errorf("unexpected comments %+v", comments)
+	}
+
+	updates := srv.Find(http.MethodPatch, repoPath+"/pulls/9")
+	if len(updates) != 1 || updates[0].Body["state"] != "closed" {
+		t.Errorf("unexpected updates %+v", updates)
+	}
+}
+
+func TestProposalStatus(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("GET "+repoPath+"/pulls/9", http.StatusOK, map[string]any{
+		"number":          9,
+		"mergeable_state": "dirty",
+		"head":            map[string]any{"sha": "abc"},
+	})
+	srv.Handle("GET "+repoPath+"/commits/abc/check-runs", http.StatusOK, map[string]any{
+		"check_runs": []map[string]any{
+			{"name": "build", "status": "completed", "conclusion": "success"},
+			{"name": "lint", "status": "completed", "conclusion": "failure"},
+			{"name": "e2e", "status": "in_progress"},
+		},
+	})
+	srv.Handle("GET "+repoPath+"/commits/abc/status", http.StatusOK, map[string]any{
+		"statuses": []map[string]any{{"context": "deploy", "state": "success"}},
+	})
+	srv.Handle("GET "+repoPath+"/pulls/9/reviews", http.StatusOK, []map[string]any{
+		{"user": map[string]any{"login": "a"}, "state": "APPROVED"},
+		{"user": map[string]any{"login": "b"}, "state": "APPROVED"},
+		// only the latest review from each reviewer counts
+		{"user": map[string]any{"login": "b"}, "state": "CHANGES_REQUESTED"},
+		{"user": map[string]any{"login": "c"}, "state": "COMMENTED"},
+	})
+
+	status, err := scm.ProposalStatus(context.Background(), &git.Proposal{
+		ExternalMetadata: map[string]any{GitHubPRNumberField: 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &git.ProposalStatus{
+		Checks: map[string]git.CheckState{
+			"build":  git.CheckStateSuccess,
+			"lint":   git.CheckStateFailure,
+			"e2e":    git.CheckStatePending,
+			"deploy": git.CheckStateSuccess,
+		},
+		Approvals:  1,
+		Conflicted: true,
+	}
+
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("unexpected status\nexpected: %+v\nfound:    %+v", expected, status)
+	}
+}
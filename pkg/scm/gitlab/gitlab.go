@@ -0,0 +1,402 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/scm/internal/rest"
+	"github.com/get-glu/glu/pkg/src/git"
+)
+
+const (
+	GitLabMRIIDField = "gitlab.mr.iid"
+)
+
+var (
+	_ git.Proposer             = (*SCM)(nil)
+	_ git.AutoMerger           = (*SCM)(nil)
+	_ git.ProposalStatusSource = (*SCM)(nil)
+)
+
+// SCM is a git.Proposer which manages proposals as GitLab merge requests.
+type SCM struct {
+	client  *rest.Client
+	project string
+}
+
+// New constructs a GitLab proposer for the project identified by its full path (e.g. group/subgroup/name).
+// The apiURL is the base of the GitLab REST API (e.g. https://gitlab.com/api/v4) and the
+// provided client is expected to authenticate requests (e.g. with a bearer access token).
+func New(client *http.Client, apiURL, project string) *SCM {
+	return &SCM{client: rest.New(client, apiURL), project: project}
+}
+
+type mergeRequest struct {
+	IID          int      `json:"iid"`
+	SourceBranch string   `json:"source_branch"`
+	TargetBranch string   `json:"target_branch"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	WebURL       string   `json:"web_url"`
+	Labels       []string `json:"labels"`
+	DiffRefs     struct {
+		BaseSHA string `json:"base_sha"`
+	} `json:"diff_refs"`
+	SHA                       string `json:"sha"`
+	HasConflicts              bool   `json:"has_conflicts"`
+	MergeWhenPipelineSucceeds bool   `json:"merge_when_pipeline_succeeds"`
+}
+
+func (s *SCM) GetCurrentProposal(ctx context.Context, baseBranch, branchPrefix string) (*git.Proposal, error) {
+	var (
+		mrs      = s.listMRs(ctx, baseBranch)
+		proposal *git.Proposal
+	)
+
+	for mr := range mrs.All() {
+		if !strings.HasPrefix(mr.SourceBranch, branchPrefix+"/") {
+			continue
+		}
+
+		// the list endpoint does not include diff refs
+		// so we fetch the merge request directly
+		if _, err := s.client.Do(ctx, http.MethodGet, s.mrPath(mr.IID), nil, nil, mr); err != nil {
+			return nil, err
+		}
+
+		proposal = toProposal(mr)
+		break
+	}
+
+	if err := mrs.Err(); err != nil {
+		return nil, err
+	}
+
+	if proposal == nil {
+		return nil, fmt.Errorf("base %q: prefix %q: %w", baseBranch, branchPrefix, git.ErrProposalNotFound)
+	}
+
+	return proposal, nil
+}
+
+func (s *SCM) ListProposals(ctx context.Context, branchPrefix string) (proposals []*git.Proposal, err error) {
+	mrs := s.listMRs(ctx, "")
+	for mr := range mrs.All() {
+		if strings.HasPrefix(mr.SourceBranch, branchPrefix+"/") {
+			proposals = append(proposals, toProposal(mr))
+		}
+	}
+
+	if err := mrs.Err(); err != nil {
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
+func toProposal(mr *mergeRequest) *git.Proposal {
+	parts := strings.Split(mr.SourceBranch, "/")
+	return &git.Proposal{
+		Number:       mr.IID,
+		URL:          mr.WebURL,
+		BaseRevision: mr.DiffRefs.BaseSHA,
+		BaseBranch:   mr.TargetBranch,
+		Branch:       mr.SourceBranch,
+		Digest:       parts[len(parts)-1],
+		Title:        mr.Title,
+		Body:         mr.Description,
+		ExternalMetadata: map[string]any{
+			GitLabMRIIDField: mr.IID,
+		},
+	}
+}
+
+type createMergeRequest struct {
+	SourceBranch       string `json:"source_branch"`
+	TargetBranch       string `json:"target_branch"`
+	Title              string `json:"title"`
+	Description        string `json:"description"`
+	Labels             string `json:"labels,omitempty"`
+	ReviewerIDs        []int  `json:"reviewer_ids,omitempty"`
+	RemoveSourceBranch bool   `json:"remove_source_branch"`
+}
+
+func (s *SCM) CreateProposal(ctx context.Context, proposal *git.Proposal, opts git.ProposalOption) error {
+	reviewers, err := s.userIDs(ctx, opts.Reviewers)
+	if err != nil {
+		return err
+	}
+
+	var mr mergeRequest
+	if _, err := s.client.Do(ctx, http.MethodPost, s.projectPath()+"/merge_requests", nil, createMergeRequest{
+		SourceBranch:       proposal.Branch,
+		TargetBranch:       proposal.BaseBranch,
+		Title:              proposal.Title,
+		Description:        proposal.Body,
+		Labels:             strings.Join(opts.Labels, ","),
+		ReviewerIDs:        reviewers,
+		RemoveSourceBranch: true,
+	}, &mr); err != nil {
+		return err
+	}
+
+	slog.Info("proposal created", "scm_type", "gitlab", "proposal_url", mr.WebURL)
+
+	proposal.Number = mr.IID
+	proposal.URL = mr.WebURL
+	proposal.ExternalMetadata = map[string]any{
+		GitLabMRIIDField: mr.IID,
+	}
+
+	return nil
+}
+
+func (s *SCM) MergeProposal(ctx context.Context, proposal *git.Proposal, opts git.MergeOption) error {
+	iid, ok := proposal.ExternalMetadata[GitLabMRIIDField].(int)
+	if !ok {
+		slog.Warn("could not merge mr", "reason", "missing MR iid on proposal")
+		return nil
+	}
+
+	if err := checkMergeMethod(opts.Method); err != nil {
+		return err
+	}
+
+	return s.merge(ctx, iid, opts.Method, false)
+}
+
+// EnableAutoMerge sets the merge request to merge once its pipeline succeeds.
+// Required checks and approvals are enforced by the projects own merge checks and approval rules.
+// It does nothing when auto-merge is already set and returns git.ErrAutoMergeNotReady while
+// GitLab refuses to set it (e.g. when the merge request is still being checked).
+func (s *SCM) EnableAutoMerge(ctx context.Context, proposal *git.Proposal, opts git.AutoMerge) error {
+	iid, ok := proposal.ExternalMetadata[GitLabMRIIDField].(int)
+	if !ok {
+		return errors.New("missing MR iid on proposal")
+	}
+
+	if err := checkMergeMethod(opts.Method); err != nil {
+		return err
+	}
+
+	var mr mergeRequest
+	if _, err := s.client.Do(ctx, http.MethodGet, s.mrPath(iid), nil, nil, &mr); err != nil {
+		return err
+	}
+
+	if mr.MergeWhenPipelineSucceeds {
+		return nil
+	}
+
+	err := s.merge(ctx, iid, opts.Method, true)
+
+	var statusErr *rest.StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusMethodNotAllowed, http.StatusNotAcceptable, http.StatusUnprocessableEntity:
+			return fmt.Errorf("%w: %w", git.ErrAutoMergeNotReady, err)
+		}
+	}
+
+	return err
+}
+
+// ProposalStatus returns the commit statuses (including pipeline jobs) of the merge requests
+// head commit, the number of approvals and whether it conflicts with its target branch.
+func (s *SCM) ProposalStatus(ctx context.Context, proposal *git.Proposal) (*git.ProposalStatus, error) {
+	iid, ok := proposal.ExternalMetadata[GitLabMRIIDField].(int)
+	if !ok {
+		return nil, errors.New("missing MR iid on proposal")
+	}
+
+	var mr mergeRequest
+	if _, err := s.client.Do(ctx, http.MethodGet, s.mrPath(iid), nil, nil, &mr); err != nil {
+		return nil, err
+	}
+
+	status := &git.ProposalStatus{
+		Checks:     map[string]git.CheckState{},
+		Conflicted: mr.HasConflicts,
+	}
+
+	query := url.Values{"per_page": []string{"100"}}
+	for {
+		var statuses []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		}
+
+		resp, err := s.client.Do(ctx, http.MethodGet, s.projectPath()+"/repository/commits/"+mr.SHA+"/statuses", query, nil, &statuses)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, st := range statuses {
+			state := git.CheckStatePending
+			switch st.Status {
+			case "success", "skipped":
+				state = git.CheckStateSuccess
+			case "failed", "canceled":
+				state = git.CheckStateFailure
+			}
+
+			status.Checks[st.Name] = state
+		}
+
+		next := resp.Header.Get("X-Next-Page")
+		if next == "" {
+			break
+		}
+
+		query.Set("page", next)
+	}
+
+	var approvals struct {
+		ApprovedBy []struct{} `json:"approved_by"`
+	}
+
+	if _, err := s.client.Do(ctx, http.MethodGet, s.mrPath(iid)+"/approvals", nil, nil, &approvals); err != nil {
+		return nil, err
+	}
+
+	status.Approvals = len(approvals.ApprovedBy)
+
+	return status, nil
+}
+
+// merge merges the merge request using the projects configured merge method.
+// The squash method additionally squashes the source branch commits.
+// checkMergeMethod returns core.ErrNotSupported for merge methods which cannot be requested
+// when merging. GitLab only rebases as a separate (asynchronous) operation, so rebase is not supported.
+func checkMergeMethod(method git.MergeMethod) error {
+	switch method {
+	case "", git.MergeMethodMerge, git.MergeMethodSquash:
+		return nil
+	default:
+		return fmt.Errorf("merge method %q: %w", method, core.ErrNotSupported)
+	}
+}
+
+func (s *SCM) merge(ctx context.Context, iid int, method git.MergeMethod, whenPipelineSucceeds bool) error {
+	_, err := s.client.Do(ctx, http.MethodPut, s.mrPath(iid)+"/merge", nil, map[string]any{
+		"should_remove_source_branch":  true,
+		"squash":                       method == git.MergeMethodSquash,
+		"merge_when_pipeline_succeeds": whenPipelineSucceeds,
+	}, nil)
+
+	return err
+}
+
+func (s *SCM) CloseProposal(ctx context.Context, proposal *git.Proposal, opts git.CloseOption) error {
+	iid, ok := proposal.ExternalMetadata[GitLabMRIIDField].(int)
+	if !ok {
+		slog.Warn("could not close mr", "reason", "missing MR iid on proposal")
+		return nil
+	}
+
+	if opts.Comment != "" {
+		if _, err := s.client.Do(ctx, http.MethodPost, s.mrPath(iid)+"/notes", nil, map[string]any{
+			"body": opts.Comment,
+		}, nil); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.client.Do(ctx, http.MethodPut, s.mrPath(iid), nil, map[string]any{
+		"state_event": "close",
+	}, nil)
+
+	return err
+}
+
+// userIDs resolves the provided usernames to GitLab user IDs.
+func (s *SCM) userIDs(ctx context.Context, usernames []string) (ids []int, err error) {
+	for _, username := range usernames {
+		var users []struct {
+			ID int `json:"id"`
+		}
+
+		if _, err := s.client.Do(ctx, http.MethodGet, "/users", url.Values{"username": []string{username}}, nil, &users); err != nil {
+			return nil, err
+		}
+
+		if len(users) == 0 {
+			return nil, fmt.Errorf("reviewer %q: user not found", username)
+		}
+
+		ids = append(ids, users[0].ID)
+	}
+
+	return
+}
+
+func (s *SCM) projectPath() string {
+	return "/projects/" + url.PathEscape(s.project)
+}
+
+func (s *SCM) mrPath(iid int) string {
+	return s.projectPath() + "/merge_requests/" + strconv.Itoa(iid)
+}
+
+type mrs struct {
+	ctx  context.Context
+	scm  *SCM
+	base string
+
+	err error
+}
+
+func (s *SCM) listMRs(ctx context.Context, base string) *mrs {
+	return &mrs{ctx, s, base, nil}
+}
+
+func (m *mrs) Err() error {
+	return m.err
+}
+
+func (m *mrs) All() iter.Seq[*mergeRequest] {
+	return iter.Seq[*mergeRequest](func(yield func(*mergeRequest) bool) {
+		query := url.Values{
+			"state":    []string{"opened"},
+			"per_page": []string{"100"},
+		}
+
+		if m.base != "" {
+			query.Set("target_branch", m.base)
+		}
+
+		for {
+			var page []*mergeRequest
+			resp, err := m.scm.client.Do(m.ctx, http.MethodGet, m.scm.projectPath()+"/merge_requests", query, nil, &page)
+			if err != nil {
+				m.err = err
+				return
+			}
+
+			for _, mr := range page {
+				if !strings.HasPrefix(mr.SourceBranch, "glu/") {
+					continue
+				}
+
+				if !yield(mr) {
+					return
+				}
+			}
+
+			next := resp.Header.Get("X-Next-Page")
+			if next == "" {
+				return
+			}
+
+			query.Set("page", next)
+		}
+	})
+}
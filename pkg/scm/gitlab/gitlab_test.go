@@ -0,0 +1,412 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/scm/internal/scmtest"
+	"github.com/get-glu/glu/pkg/src/git"
+)
+
+const projectPath = "/api/v4/projects/group%2Fsubgroup%2Fname"
+
+func newServer(t *testing.T) (*scmtest.Server, *SCM) {
+	t.Helper()
+
+	srv := scmtest.NewServer(t)
+
+	return srv, New(srv.Client(), srv.URL()+"/api/v4", "group/subgroup/name")
+}
+
+func mr(iid int, source, target string) map[string]any {
+	return map[string]any{
+		"iid":           iid,
+		"source_branch": source,
+		"target_branch": target,
+		"title":         "Update " + target,
+		"description":   "body",
+		"web_url":       "https://gitlab.example.com/mr/" + source,
+		"diff_refs":     map[string]any{"base_sha": "base-" + source},
+	}
+}
+
+func TestCreateProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("GET /api/v4/users", http.StatusOK, []map[string]any{{"id": 42}})
+	srv.Handle("POST "+projectPath+"/merge_requests", http.StatusCreated, map[string]any{
+		"iid":     7,
+		"web_url": "https://gitlab.example.com/mr/7",
+	})
+
+	proposal := &git.Proposal{
+		BaseBranch: "main",
+		Branch:     "glu/production/abc",
+		Title:      "Update production",
+		Body:       "body",
+	}
+
+	if err := scm.CreateProposal(context.Background(), proposal, git.ProposalOption{
+		Labels:    []string{"glu", "production"},
+		Reviewers: []string{"octocat"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if proposal.Number != 7 || proposal.URL != "https://gitlab.example.com/mr/7" {
+		t.Errorf("unexpected proposal %+v", proposal)
+	}
+
+	if iid := proposal.ExternalMetadata[GitLabMRIIDField]; iid != 7 {
+		t.Errorf("expected MR iid 7 in metadata, found %v", iid)
+	}
+
+	if users := srv.Find(http.MethodGet, "/api/v4/users"); len(users) != 1 || users[0].Query != "username=octocat" {
+		t.Errorf("unexpected user lookups %+v", users)
+	}
+
+	created := srv.Find(http.MethodPost, projectPath+"/merge_requests")
+	if len(created) != 1 {
+		t.Fatalf("expected one create request, found %d", len(created))
+	}
+
+	expected := map[string]any{
+		"source_branch":        "glu/production/abc",
+		"target_branch":        "main",
+		"title":                "Update production",
+		"description":          "body",
+		"labels":               "glu,production",
+		"reviewer_ids":         []any{float64(42)},
+		"remove_source_branch": true,
+	}
+
+	if !reflect.DeepEqual(created[0].Body, expected) {
+		t.Errorf("unexpected create body\nexpected: %v\nfound:    %v", expected, created[0].Body)
+	}
+}
+
+func TestCreateProposal_UnknownReviewer(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("GET /api/v4/users", http.StatusOK, []map[string]any{})
+
+	err := scm.CreateProposal(context.Background(), &git.Proposal{}, git.ProposalOption{Reviewers: []string{"ghost"}})
+	if err == nil {
+		t.Fatal("expected error for unknown reviewer")
+	}
+
+	if created := srv.Find(http.MethodPost, projectPath+"/merge_requests"); len(created) > 0 {
+		t.Error("expected no merge request to be created")
+	}
+}
+
+func TestListProposals(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.HandleFunc("GET "+projectPath+"/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		var page []map[string]any
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("X-Next-Page", "2")
+			page = []map[string]any{
+				mr(1, "glu/production/aaa", "main"),
+				mr(2, "feature/unrelated", "main"),
+			}
+		case "2":
+			page = []map[string]any{
+				mr(3, "glu/staging/bbb", "main"),
+				mr(4, "glu/productionfoo/ccc", "main"),
+			}
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+
+		_ = json.NewEncoder(w).Encode(page)
+	})
+
+	proposals, err := scm.ListProposals(context.Background(), "glu/production")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(proposals) != 1 {
+		t.Fatalf("expected one proposal, found %d", len(proposals))
+	}
+
+	expected := &git.Proposal{
+		Number:       1,
+		URL:          "https://gitlab.example.com/mr/glu/production/aaa",
+		BaseRevision: "base-glu/production/aaa",
+		BaseBranch:   "main",
+		Branch:       "glu/production/aaa",
+		Digest:       "aaa",
+		Title:        "Update main",
+		Body:         "body",
+		ExternalMetadata: map[string]any{
+			GitLabMRIIDField: 1,
+		},
+	}
+
+	if !reflect.DeepEqual(proposals[0], expected) {
+		t.Errorf("unexpected proposal\nexpected: %+v\nfound:    %+v", expected, proposals[0])
+	}
+
+	if pages := srv.Find(http.MethodGet, projectPath+"/merge_requests"); len(pages) != 2 {
+		t.Errorf("expected two pages to be requested, found %d", len(pages))
+	}
+
+	proposals, err = scm.ListProposals(context.Background(), "glu/staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(proposals) != 1 || proposals[0].Number != 3 {
+		t.Errorf("expected proposal 3 from the second page, found %+v", proposals)
+	}
+}
+
+func TestGetCurrentProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("GET "+projectPath+"/merge_requests", http.StatusOK, []map[string]any{
+		mr(1, "glu/staging/aaa", "main"),
+		// a sibling phase which shares the prefix of the requested phase
+		mr(3, "glu/production-eu/ccc", "main"),
+		mr(2, "glu/production/bbb", "main"),
+	})
+	srv.Handle("GET "+projectPath+"/merge_requests/2", http.StatusOK, mr(2, "glu/production/bbb", "main"))
+
+	proposal, err := scm.GetCurrentProposal(context.Background(), "main", "glu/production")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if proposal.Number != 2 || proposal.BaseRevision != "base-glu/production/bbb" || proposal.Digest != "bbb" {
+		t.Errorf("unexpected proposal %+v", proposal)
+	}
+
+	list := srv.Find(http.MethodGet, projectPath+"/merge_requests")
+	if len(list) != 1 || list[0].Query != "per_page=100&state=opened&target_branch=main" {
+		t.Errorf("unexpected list requests %+v", list)
+	}
+
+	if _, err := scm.GetCurrentProposal(context.Background(), "main", "glu/development"); !errors.Is(err, git.ErrProposalNotFound) {
+		t.Errorf("expected proposal not found, found %v", err)
+	}
+}
+
+func TestListProposals_Error(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("GET "+projectPath+"/merge_requests", http.StatusUnauthorized, map[string]any{"message": "401 Unauthorized"})
+
+	if _, err := scm.ListProposals(context.Background(), "glu/production"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestMergeProposal(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		method git.MergeMethod
+		squash bool
+	}{
+		{name: "merge", method: git.MergeMethodMerge},
+		{name: "squash", method: git.MergeMethodSquash, squash: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			srv, scm := newServer(t)
+			srv.Handle("PUT "+projectPath+"/merge_requests/7/merge", http.StatusOK, map[string]any{"iid": 7})
+
+			if err := scm.MergeProposal(context.Background(), &git.Proposal{
+				ExternalMetadata: map[string]any{GitLabMRIIDField: 7},
+			}, git.MergeOption{Method: test.method}); err != nil {
+				t.Fatal(err)
+			}
+
+			merges := srv.Find(http.MethodPut, projectPath+"/merge_requests/7/merge")
+			if len(merges) != 1 {
+				t.Fatalf("expected one merge request, found %d", len(merges))
+			}
+
+			expected := map[string]any{
+				"should_remove_source_branch":  true,
+				"squash":                       test.squash,
+				"merge_when_pipeline_succeeds": false,
+			}
+
+			if !reflect.DeepEqual(merges[0].Body, expected) {
+				t.Errorf("unexpected merge body\nexpected: %v\nfound:    %v", expected, merges[0].Body)
+			}
+		})
+	}
+}
+
+func TestMergeProposal_UnsupportedMethod(t *testing.T) {
+	srv, scm := newServer(t)
+
+	proposal := &git.Proposal{ExternalMetadata: map[string]any{GitLabMRIIDField: 7}}
+
+	if err := scm.MergeProposal(context.Background(), proposal, git.MergeOption{Method: git.MergeMethodRebase}); !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("expected not supported, found %v", err)
+	}
+
+	if err := scm.EnableAutoMerge(context.Background(), proposal, git.AutoMerge{Method: git.MergeMethodRebase}); !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("expected not supported, found %v", err)
+	}
+
+	// the merge request must not be merged using a different method
+	if len(srv.Requests()) > 0 {
+		t.Errorf("expected no requests, found %+v", srv.Requests())
+	}
+}
+
+func TestMergeProposal_MissingIID(t *testing.T) {
+	srv, scm := newServer(t)
+
+	if err := scm.MergeProposal(context.Background(), &git.Proposal{}, git.MergeOption{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(srv.Requests()) > 0 {
+		t.Errorf("expected no requests, found %+v", srv.Requests())
+	}
+}
+
+func TestCloseProposal(t *testing.T) {
+	srv, scm := newServer(t)
+	srv.Handle("POST "+projectPath+"/merge_requests/7/notes", http.StatusCreated, map[string]any{"id": 1})
+	srv.Handle("PUT "+projectPath+"/merge_requests/7", http.StatusOK, map[string]any{"iid": 7})
+
+	if err := scm.CloseProposal(context.Background(), &git.Proposal{
+		ExternalMetadata: map[string]any{GitLabMRIIDField: 7},
+	}, git.CloseOption{Comment: "superseded"}); err != nil {
+		t.Fatal(err)
+	}
+
+	notes := srv.Find(http.MethodPost, projectPath+"/merge_requests/7/notes")
+	if len(notes) != 1 || notes[0].Body["body"] != "superseded" {
+		t.Errorf("unexpected notes %+v", notes)
+	}
+
+	updates := srv.Find(http.MethodPut, projectPath+"/merge_requests/7")
+	if len(updates) != 1 || updates[0].Body["state_event"] != "close" {
+		t.Errorf("unexpected updates %+v", updates)
+	}
+}
+
+func TestEnableAutoMerge(t *testing.T) {
+	proposal := &git.Proposal{ExternalMetadata: map[string]any{GitLabMRIIDField: 7}}
+
+	t.Run("enables merge when pipeline succeeds", func(t *testing.T) {
+		srv, scm := newServer(t)
+		srv.Handle("GET "+projectPath+"/merge_requests/7", http.StatusOK, mr(7, "glu/production/abc", "main"))
+		srv.Handle("PUT "+projectPath+"/merge_requests/7/merge", http.StatusOK, map[string]any{"iid": 7})
+
+		if err := scm.EnableAutoMerge(context.Background(), proposal, git.AutoMerge{Method: git.MergeMethodSquash}); err != nil {
+			t.Fatal(err)
+		}
+
+		merges := srv.Find(http.MethodPut, projectPath+"/merge_requests/7/merge")
+		if len(merges) != 1 || merges[0].Body["merge_when_pipeline_succeeds"] != true || merges[0].Body["squash"] != true {
+			t.Errorf("unexpected merge requests %+v", merges)
+		}
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		srv, scm := newServer(t)
+		enabled := mr(7, "glu/production/abc", "main")
+		enabled["merge_when_pipeline_succeeds"] = true
+		srv.Handle("GET "+projectPath+"/merge_requests/7", http.StatusOK, enabled)
+
+		if err := scm.EnableAutoMerge(context.Background(), proposal, git.AutoMerge{}); err != nil {
+			t.Fatal(err)
+		}
+
+		if merges := srv.Find(http.MethodPut, projectPath+"/merge_requests/7/merge"); len(merges) > 0 {
+			t.Errorf("expected no merge requests, found %+v", merges)
+		}
+	})
+
+	for _, status := range []int{http.StatusMethodNotAllowed, http.StatusNotAcceptable, http.StatusUnprocessableEntity} {
+		t.Run("not ready "+http.StatusText(status), func(t *testing.T) {
+			srv, scm := newServer(t)
+			srv.Handle("GET "+projectPath+"/merge_requests/7", http.StatusOK, mr(7, "glu/production/abc", "main"))
+			srv.Handle("PUT "+projectPath+"/merge_requests/7/merge", status, map[string]any{"message": "Method Not Allowed"})
+
+			if err := scm.EnableAutoMerge(context.Background(), proposal, git.AutoMerge{}); !errors.Is(err, git.ErrAutoMergeNotReady) {
+				t.Errorf("expected auto-merge not ready, found %v", err)
+			}
+		})
+	}
+
+	t.Run("unexpected error", func(t *testing.T) {
+		srv, scm := newServer(t)
+		srv.Handle("GET "+projectPath+"/merge_requests/7", http.StatusOK, mr(7, "glu/production/abc", "main"))
+		srv.Handle("PUT "+projectPath+"/merge_requests/7/merge", http.StatusUnauthorized, map[string]any{"message": "401 Unauthorized"})
+
+		err := scm.EnableAutoMerge(context.Background(), proposal, git.AutoMerge{})
+		if err == nil || errors.Is(err, git.ErrAutoMergeNotReady) {
+			t.Errorf("expected unauthorized error, found %v", err)
+		}
+	})
+}
+
+func TestProposalStatus(t *testing.T) {
+	srv, scm := newServer(t)
+
+	conflicted := mr(7, "glu/production/abc", "main")
+	conflicted["sha"] = "head"
+	conflicted["has_conflicts"] = true
+	srv.Handle("GET "+projectPath+"/merge_requests/7", http.StatusOK, conflicted)
+	srv.Handle("GET "+projectPath+"/merge_requests/7/approvals", http.StatusOK, map[string]any{
+		"approved_by": []map[string]any{
+			{"user": map[string]any{"username": "a"}},
+			{"user": map[string]any{"username": "b"}},
+		},
+	})
+	srv.HandleFunc("GET "+projectPath+"/repository/commits/head/statuses", func(w http.ResponseWriter, r *http.Request) {
+		var page []map[string]any
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("X-Next-Page", "2")
+			page = []map[string]any{
+				{"name": "build", "status": "success"},
+				{"name": "lint", "status": "skipped"},
+				{"name": "test", "status": "running"},
+			}
+		case "2":
+			page = []map[string]any{
+				{"name": "deploy", "status": "failed"},
+				{"name": "scan", "status": "canceled"},
+				{"name": "external", "status": "pending"},
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(page)
+	})
+
+	status, err := scm.ProposalStatus(context.Background(), &git.Proposal{
+		ExternalMetadata: map[string]any{GitLabMRIIDField: 7},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &git.ProposalStatus{
+		Checks: map[string]git.CheckState{
+			"build":    git.CheckStateSuccess,
+			"lint":     git.CheckStateSuccess,
+			"test":     git.CheckStatePending,
+			"deploy":   git.CheckStateFailure,
+			"scan":     git.CheckStateFailure,
+			"external": git.CheckStatePending,
+		},
+		Approvals:  2,
+		Conflicted: true,
+	}
+
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("unexpected status\nexpected: %+v\nfound:    %+v", expected, status)
+	}
+}
@@ -0,0 +1,97 @@
+// Package rest contains a minimal JSON over HTTP client shared by the
+// SCM proposer implementations which do not have a dedicated client library.
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// StatusError is returned when a request completes with an unexpected status code.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s: unexpected status %d: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// Client performs JSON requests relative to a base URL.
+type Client struct {
+	client  *http.Client
+	baseURL string
+}
+
+// New returns a client which resolves request paths relative to baseURL.
+// The provided HTTP client is expected to handle authentication.
+func New(client *http.Client, baseURL string) *Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &Client{client: client, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Do performs a request with body encoded as JSON (when non-nil) and decodes
+// the response into out (when non-nil).
+// Responses with a non-2xx status code produce a *StatusError.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body, out any) (*http.Response, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var rd io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		rd = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, rd)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return resp, &StatusError{
+			Method:     method,
+			URL:        u,
+			StatusCode: resp.StatusCode,
+			Body:       strings.TrimSpace(string(data)),
+		}
+	}
+
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("decoding response: %w", err)
+		}
+	}
+
+	return resp, nil
+}
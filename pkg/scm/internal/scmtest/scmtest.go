@@ -0,0 +1,150 @@
+// Package scmtest provides a fake SCM API for testing the SCM implementations.
+package scmtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// Request is a request received by a Server.
+type Request struct {
+	Method string
+	// Path is the escaped path of the request
+	Path  string
+	Query string
+	// Raw is the request body as received
+	Raw []byte
+	// Body is the request body decoded when it is a JSON object
+	Body map[string]any
+}
+
+// Server is a fake SCM API which records requests and serves the registered handlers.
+type Server struct {
+	t   *testing.T
+	srv *httptest.Server
+	mux *http.ServeMux
+
+	mu       sync.Mutex
+	requests []Request
+}
+
+// NewServer starts a Server which is closed when the test completes.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+
+	s := &Server{t: t, mux: http.NewServeMux()}
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := Request{Method: r.Method, Path: r.URL.EscapedPath(), Query: r.URL.RawQuery}
+		if r.Body != nil {
+			raw, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Errorf("reading request body: %v", err)
+			}
+
+			req.Raw = raw
+			if bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+				if err := json.Unmarshal(raw, &req.Body); err != nil {
+					t.Errorf("decoding request body: %v", err)
+				}
+			}
+
+			// handlers can still read the body
+			r.Body = io.NopCloser(bytes.NewReader(raw))
+		}
+
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		s.mu.Unlock()
+
+		s.mux.ServeHTTP(w, r)
+	}))
+
+	t.Cleanup(s.srv.Close)
+
+	return s
+}
+
+// URL returns the base URL of the server.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Client returns an HTTP client configured to make requests to the server.
+func (s *Server) Client() *http.Client {
+	return s.srv.Client()
+}
+
+// HandleFunc registers fn for requests matching pattern.
+func (s *Server) HandleFunc(pattern string, fn http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, fn)
+}
+
+// Handle responds to requests matching pattern with status and body encoded as JSON.
+func (s *Server) Handle(pattern string, status int, body any) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		if body != nil {
+			if err := json.NewEncoder(w).Encode(body); err != nil {
+				s.t.Errorf("encoding response: %v", err)
+			}
+		}
+	})
+}
+
+// Paginate serves each of pages in turn based on the page query parameter
+// and advertises the next page using the Link header.
+func (s *Server) Paginate(pattern string, pages ...any) {
+	s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			var err error
+			if page, err = strconv.Atoi(p); err != nil {
+				page = 0
+			}
+		}
+
+		if page < 1 || page > len(pages) {
+			s.t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if page < len(pages) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%d>; rel="next"`, s.srv.URL, r.URL.Path, page+1))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(pages[page-1])
+	})
+}
+
+// Requests returns every request received by the server.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// Find returns the requests received with the given method and (escaped) path.
+func (s *Server) Find(method, path string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var found []Request
+	for _, r := range s.requests {
+		if r.Method == method && r.Path == path {
+			found = append(found, r)
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,197 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/get-glu/glu/internal/git"
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/fs"
+	"github.com/get-glu/glu/pkg/phases"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var _ phases.HistorySource[Resource] = (*Source[Resource])(nil)
+
+// History returns the previous states of the resource for the provided phase.
+// It first reads the current state of the resource while recording the paths it accesses.
+// It then walks the commits on the resources branch which touch any of these paths
+// and decodes the resource at each of them.
+// Consecutive commits which produce the same digest are collapsed into the oldest
+// commit, which is the revision at which that state was introduced.
+// The result is cached until the head of the resources branch moves.
+func (g *Source[A]) History(ctx context.Context, pipeline, phase core.Metadata, newFn func() A) (states []core.State, err error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	// perform an initial fetch to ensure we're up to date
+	if err := g.repo.Fetch(ctx); err != nil {
+		return nil, err
+	}
+
+	current := newFn()
+
+	branch := g.repo.DefaultBranch()
+	if branched, ok := core.Resource(current).(Branched); ok {
+		branch = branched.Branch()
+	}
+
+	head, err := g.repo.Resolve(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	// sources may be shared between pipelines with phases of the same name
+	key := pipeline.Name + "/" + phase.Name
+
+	g.historyMu.Lock()
+	cached, ok := g.history[key]
+	g.historyMu.Unlock()
+
+	if ok && cached.head == head {
+		return slices.Clone(cached.states), nil
+	}
+
+	defer func() {
+		if err != nil {
+			return
+		}
+
+		g.historyMu.Lock()
+		defer g.historyMu.Unlock()
+
+		if g.history == nil {
+			g.history = map[string]historyEntry{}
+		}
+
+		g.history[key] = historyEntry{head: head, states: slices.Clone(states)}
+	}()
+
+	paths := &pathRecorder{paths: map[string]struct{}{}}
+	if err := g.repo.View(ctx, func(_ plumbing.Hash, fs fs.Filesystem) error {
+		return current.ReadFrom(ctx, phase, &recordingFilesystem{fs, paths})
+	}, git.WithBranch(branch)); err != nil {
+		return nil, err
+	}
+
+	commits, err := g.repo.ListCommits(ctx, branch, "", paths.matches)
+	if err != nil {
+		return nil, err
+	}
+
+	for commit := range commits {
+		var (
+			r    = newFn()
+			hash = commit.Hash
+		)
+
+		if err := g.repo.View(ctx, func(_ plumbing.Hash, fs fs.Filesystem) error {
+			return r.ReadFrom(ctx, phase, fs)
+		}, git.WithRevision(&hash)); err != nil {
+			// the resource could not be read at this revision
+			// so we assume this marks the beginning of its history
+			slog.Debug("stopping history", "revision", hash, "reason", err)
+
+			break
+		}
+
+		digest, err := r.Digest()
+		if err != nil {
+			return nil, err
+		}
+
+		state := core.State{
+			Revision:  hash.String(),
+			Digest:    digest,
+			Timestamp: commit.Committer.When,
+			Message:   strings.TrimSpace(commit.Message),
+			Resource:  r,
+		}
+
+		if n := len(states); n > 0 && states[n-1].Digest == digest {
+			states[n-1] = state
+			continue
+		}
+
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+// historyEntry is the history of a phase as walked from head.
+type historyEntry struct {
+	head   plumbing.Hash
+	states []core.State
+}
+
+// ViewRevision reads the state of the resource for the provided phase
+// at the provided commit revision.
+func (g *Source[A]) ViewRevision(ctx context.Context, _, phase core.Metadata, revision string, r A) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !plumbing.IsHash(revision) {
+		return fmt.Errorf("revision %q: expected full commit hash", revision)
+	}
+
+	// perform an initial fetch to ensure we're up to date
+	if err := g.repo.Fetch(ctx); err != nil {
+		return err
+	}
+
+	hash := plumbing.NewHash(revision)
+
+	return g.repo.View(ctx, func(_ plumbing.Hash, fs fs.Filesystem) error {
+		return r.ReadFrom(ctx, phase, fs)
+	}, git.WithRevision(&hash))
+}
+
+// pathRecorder keeps track of the set of paths accessed through a recordingFilesystem.
+type pathRecorder struct {
+	paths map[string]struct{}
+}
+
+func (p *pathRecorder) record(name string) {
+	p.paths[strings.TrimPrefix(path.Clean("/"+name), "/")] = struct{}{}
+}
+
+// matches returns true if the provided path was either recorded directly
+// or is contained within a recorded directory.
+func (p *pathRecorder) matches(name string) bool {
+	for recorded := range p.paths {
+		if recorded == "" || name == recorded || strings.HasPrefix(name, recorded+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recordingFilesystem decorates a filesystem and records each path
+// accessed through it.
+type recordingFilesystem struct {
+	fs.Filesystem
+
+	recorder *pathRecorder
+}
+
+func (r *recordingFilesystem) OpenFile(filename string, flag int, perm os.FileMode) (fs.File, error) {
+	r.recorder.record(filename)
+	return r.Filesystem.OpenFile(filename, flag, perm)
+}
+
+func (r *recordingFilesystem) Stat(filename string) (os.FileInfo, error) {
+	r.recorder.record(filename)
+	return r.Filesystem.Stat(filename)
+}
+
+func (r *recordingFilesystem) ReadDir(path string) ([]os.FileInfo, error) {
+	r.recorder.record(path)
+	return r.Filesystem.ReadDir(path)
+}
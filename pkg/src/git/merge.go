@@ -0,0 +1,234 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/get-glu/glu/internal/git"
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/events"
+	"github.com/get-glu/glu/pkg/fs"
+)
+
+// ErrAutoMergeNotReady is returned by an AutoMerger when the SCM cannot yet enable
+// auto-merge for a proposal (e.g. while it is still checking mergeability).
+var ErrAutoMergeNotReady = errors.New("auto-merge not ready")
+
+// MergeMethod is the strategy used to integrate a proposal into its base branch.
+type MergeMethod string
+
+const (
+	MergeMethodMerge  = MergeMethod("merge")
+	MergeMethodSquash = MergeMethod("squash")
+	MergeMethodRebase = MergeMethod("rebase")
+)
+
+// MergeOption configures how a proposal is merged.
+type MergeOption struct {
+	Method MergeMethod
+}
+
+// AutoMerge configures a proposal to be merged once it satisfies the required conditions.
+// When RequiredChecks is empty, then every check reported on the proposal must succeed.
+type AutoMerge struct {
+	Method            MergeMethod
+	RequiredChecks    []string
+	RequiredApprovals int
+}
+
+// CheckState is the state of a check (CI job or commit status) reported on a proposal.
+type CheckState string
+
+const (
+	CheckStatePending = CheckState("pending")
+	CheckStateSuccess = CheckState("success")
+	CheckStateFailure = CheckState("failure")
+)
+
+// ProposalStatus is the mergeability state of an open proposal.
+type ProposalStatus struct {
+	// Checks maps each reported check name to its state
+	Checks map[string]CheckState
+	// Approvals is the number of approving reviews
+	Approvals int
+	// Conflicted is true when the proposal cannot be merged due to conflicts with its base
+	Conflicted bool
+}
+
+// combined returns failure if any check failed, pending if any check has yet to complete
+// and otherwise success. It returns an empty state when no checks are reported.
+func (s *ProposalStatus) combined() (state CheckState) {
+	for _, check := range s.Checks {
+		switch check {
+		case CheckStateFailure:
+			return CheckStateFailure
+		case CheckStatePending:
+			state = CheckStatePending
+		case CheckStateSuccess:
+			if state == "" {
+				state = CheckStateSuccess
+			}
+		}
+	}
+
+	return state
+}
+
+// ProposalStatusSource is an optional interface for a Proposer which
+// can report the status of a proposal. It is required by glu managed auto-merge.
+type ProposalStatusSource interface {
+	ProposalStatus(context.Context, *Proposal) (*ProposalStatus, error)
+}
+
+// AutoMerger is an optional interface for a Proposer which can enable
+// native auto-merge for a proposal on the SCM itself.
+// When implemented, glu enables it instead of merging proposals itself, unless the
+// auto-merge options carry required checks or approvals for glu to evaluate.
+// EnableAutoMerge is called again for existing proposals and so must be idempotent.
+type AutoMerger interface {
+	EnableAutoMerge(context.Context, *Proposal, AutoMerge) error
+}
+
+// nativeAutoMerger returns the proposers AutoMerger when merging should be left to the SCM.
+// Required checks and approvals are glu managed conditions, which the SCMs native
+// auto-merge does not evaluate, so they require the proposer to report its status instead.
+func (g *Source[A]) nativeAutoMerger() (AutoMerger, bool) {
+	merger, ok := g.proposer.(AutoMerger)
+	if !ok {
+		return nil, false
+	}
+
+	if opts := g.proposalOptions.AutoMerge; len(opts.RequiredChecks) > 0 || opts.RequiredApprovals > 0 {
+		if _, ok := g.proposer.(ProposalStatusSource); ok {
+			return nil, false
+		}
+	}
+
+	return merger, true
+}
+
+// enableAutoMerge enables native auto-merge for the proposal.
+// When the SCM is not yet ready it is left to be retried on a subsequent promotion.
+func (g *Source[A]) enableAutoMerge(ctx context.Context, merger AutoMerger, phase core.Metadata, proposal *Proposal) error {
+	if err := merger.EnableAutoMerge(ctx, proposal, *g.proposalOptions.AutoMerge); err != nil {
+		if errors.Is(err, ErrAutoMergeNotReady) {
+			slog.Info("deferring auto-merge", "name", phase.Name, "branch", proposal.Branch, "reason", err)
+			return nil
+		}
+
+		return fmt.Errorf("enabling auto-merge: %w", err)
+	}
+
+	return nil
+}
+
+// ready returns true when the status satisfies the auto-merge conditions,
+// otherwise it returns a reason for why it does not.
+func (a *AutoMerge) ready(status *ProposalStatus) (bool, string) {
+	required := a.RequiredChecks
+	if len(required) == 0 {
+		for name := range status.Checks {
+			required = append(required, name)
+		}
+	}
+
+	var pending []string
+	for _, name := range required {
+		switch state, ok := status.Checks[name]; {
+		case !ok, state == CheckStatePending:
+			pending = append(pending, name)
+		case state == CheckStateFailure:
+			return false, fmt.Sprintf("check %q failed", name)
+		}
+	}
+
+	if len(pending) > 0 {
+		return false, fmt.Sprintf("waiting on checks [%s]", strings.Join(pending, ", "))
+	}
+
+	if status.Approvals < a.RequiredApprovals {
+		return false, fmt.Sprintf("%d of %d required approvals", status.Approvals, a.RequiredApprovals)
+	}
+
+	return true, ""
+}
+
+// autoMerge merges an existing up-to-date proposal once its status satisfies the configured
+// auto-merge conditions. Conflicted proposals are rebuilt on the head of the base branch.
+func (g *Source[A]) autoMerge(ctx context.Context, pipeline, phase core.Metadata, proposal *Proposal, update func(fs.Filesystem) (string, error)) error {
+	opts := g.proposalOptions.AutoMerge
+
+	if merger, ok := g.nativeAutoMerger(); ok {
+		// merging is handled natively by the SCM, which may not have
+		// been ready to enable it when the proposal was created
+		return g.enableAutoMerge(ctx, merger, phase, proposal)
+	}
+
+	statuser, ok := g.proposer.(ProposalStatusSource)
+	if !ok {
+		slog.Warn("skipping auto-merge", "name", phase.Name, "reason", "proposer does not support proposal status")
+		return nil
+	}
+
+	status, err := statuser.ProposalStatus(ctx, proposal)
+	if err != nil {
+		return fmt.Errorf("auto-merge: getting proposal status: %w", err)
+	}
+
+	if status.Conflicted {
+		slog.Info("rebasing conflicted proposal", "name", phase.Name, "branch", proposal.Branch)
+
+		if _, err := g.repo.UpdateAndPush(ctx, update,
+			git.WithBranch(proposal.Branch),
+			git.WithRebaseOnto(proposal.BaseBranch),
+			withActor(ctx),
+		); err != nil {
+			return fmt.Errorf("auto-merge: rebasing proposal: %w", err)
+		}
+
+		g.publishProposal(ctx, events.ProposalRebased, pipeline, phase, proposal, nil)
+
+		return nil
+	}
+
+	if ready, reason := opts.ready(status); !ready {
+		slog.Debug("skipping auto-merge", "name", phase.Name, "reason", reason)
+		return nil
+	}
+
+	if err := g.proposer.MergeProposal(ctx, proposal, MergeOption{Method: opts.Method}); err != nil {
+		return fmt.Errorf("auto-merge: %w", err)
+	}
+
+	slog.Info("proposal merged", "name", phase.Name, "branch", proposal.Branch, "method", opts.Method)
+
+	g.publishProposal(ctx, events.ProposalMerged, pipeline, phase, proposal, map[string]string{
+		"method": string(opts.Method),
+	})
+
+	return nil
+}
+
+func (g *Source[A]) publishProposal(ctx context.Context, typ events.Type, pipeline, phase core.Metadata, proposal *Proposal, attrs map[string]string) {
+	if attrs == nil {
+		attrs = map[string]string{}
+	}
+
+	attrs["base_branch"] = proposal.BaseBranch
+	attrs["branch"] = proposal.Branch
+	attrs["to_digest"] = proposal.Digest
+
+	if proposal.URL != "" {
+		attrs["url"] = proposal.URL
+	}
+
+	events.Publish(ctx, events.Event{
+		Type:       typ,
+		Pipeline:   pipeline.Name,
+		Phase:      phase.Name,
+		Attributes: attrs,
+	})
+}
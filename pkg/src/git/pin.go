@@ -0,0 +1,112 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/get-glu/glu/internal/git"
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/fs"
+	"github.com/get-glu/glu/pkg/phases"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var _ phases.PinSource[Resource] = (*Source[Resource])(nil)
+
+// pinsDir is the directory in the repositories default branch
+// which contains the pin state for each pipeline phase.
+const pinsDir = ".glu/pins"
+
+func pinPath(pipeline, phase core.Metadata) string {
+	return path.Join(pinsDir, pipeline.Name, phase.Name+".json")
+}
+
+// GetPin reads the pin for the provided phase from the repositories default branch.
+// It returns nil when the phase has not been pinned.
+func (g *Source[A]) GetPin(ctx context.Context, pipeline, phase core.Metadata) (pin *core.Pin, err error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	// perform an initial fetch to ensure we're up to date
+	if err := g.repo.Fetch(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := g.repo.View(ctx, func(_ plumbing.Hash, fs fs.Filesystem) error {
+		fi, err := fs.OpenFile(pinPath(pipeline, phase), os.O_RDONLY, 0644)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		defer fi.Close()
+
+		pin = &core.Pin{}
+		return json.NewDecoder(fi).Decode(pin)
+	}); err != nil {
+		return nil, fmt.Errorf("reading pin: %w", err)
+	}
+
+	return pin, nil
+}
+
+// SetPin writes the provided pin for the phase to the repositories default branch.
+// When pin is nil, any existing pin for the phase is removed.
+// Pins are committed directly and cannot be proposed, so sources which propose changes
+// (typically those with a protected default branch) do not support pinning.
+func (g *Source[A]) SetPin(ctx context.Context, pipeline, phase core.Metadata, pin *core.Pin) error {
+	if g.proposeChange {
+		return fmt.Errorf("pins are committed directly to %q and cannot be proposed: %w", g.repo.DefaultBranch(), core.ErrNotSupported)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	// perform an initial fetch to ensure we're up to date
+	if err := g.repo.Fetch(ctx); err != nil {
+		return fmt.Errorf("fetching upstream during pin: %w", err)
+	}
+
+	if _, err := g.repo.UpdateAndPush(ctx, func(fs fs.Filesystem) (string, error) {
+		if pin == nil {
+			return pinMessage(ctx, pipeline, phase, fmt.Sprintf("Unpin %s", phase.Name)), fs.Remove(pinPath(pipeline, phase))
+		}
+
+		fi, err := fs.OpenFile(pinPath(pipeline, phase), os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
+		if err != nil {
+			return "", err
+		}
+
+		enc := json.NewEncoder(fi)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(pin); err != nil {
+			_ = fi.Close()
+			return "", err
+		}
+
+		return pinMessage(ctx, pipeline, phase, fmt.Sprintf("Pin %s to %s", phase.Name, pin.Digest)), fi.Close()
+	}, withActor(ctx)); err != nil {
+		if errors.Is(err, git.ErrEmptyCommit) {
+			// pin state is already up to date
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func pinMessage(ctx context.Context, pipeline, phase core.Metadata, message string) string {
+	return withTrailers(ctx, message,
+		"Glu-Pipeline", pipeline.Name,
+		"Glu-Phase", phase.Name,
+	)
+}
@@ -0,0 +1,55 @@
+package git
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/phases"
+)
+
+var _ phases.ProposalSource[Resource] = (*Source[Resource])(nil)
+
+// Proposals returns the open proposals for the phase.
+// When the proposer supports it, each proposal includes its checks, approvals and mergeability.
+// Sources which do not propose changes have no proposals.
+func (g *Source[A]) Proposals(ctx context.Context, pipeline, phase core.Metadata) ([]core.Proposal, error) {
+	if !g.proposeChange || g.proposer == nil {
+		return nil, nil
+	}
+
+	proposals, err := g.proposer.ListProposals(ctx, proposalPrefix(pipeline, phase))
+	if err != nil {
+		return nil, fmt.Errorf("listing proposals: %w", err)
+	}
+
+	statuser, _ := g.proposer.(ProposalStatusSource)
+
+	results := make([]core.Proposal, 0, len(proposals))
+	for _, proposal := range proposals {
+		result := core.Proposal{
+			Number:       proposal.Number,
+			URL:          proposal.URL,
+			Branch:       proposal.Branch,
+			BaseBranch:   proposal.BaseBranch,
+			BaseRevision: proposal.BaseRevision,
+			Digest:       proposal.Digest,
+		}
+
+		if statuser != nil {
+			status, err := statuser.ProposalStatus(ctx, proposal)
+			if err != nil {
+				return nil, fmt.Errorf("proposal %q status: %w", proposal.Branch, err)
+			}
+
+			mergeable := !status.Conflicted
+			result.Checks = string(status.combined())
+			result.Approvals = status.Approvals
+			result.Mergeable = &mergeable
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
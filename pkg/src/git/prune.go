@@ -0,0 +1,81 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/events"
+	"github.com/get-glu/glu/pkg/phases"
+)
+
+var _ phases.PrunableSource[Resource] = (*Source[Resource])(nil)
+
+// proposalPrefix returns the branch prefix beneath which proposals for the phase are created.
+func proposalPrefix(pipeline, phase core.Metadata) string {
+	return fmt.Sprintf("glu/%s/%s", pipeline.Name, phase.Name)
+}
+
+// Prune closes every open proposal for the phase which does not propose the pending digest
+// and deletes its branch. It returns the branches of the closed proposals (or of those
+// which would be closed when dryRun is true).
+// Sources which do not propose changes have nothing to prune.
+func (g *Source[A]) Prune(ctx context.Context, pipeline, phase core.Metadata, pending string, dryRun bool) (pruned []string, err error) {
+	if !g.proposeChange || g.proposer == nil {
+		return nil, nil
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	proposals, err := g.proposer.ListProposals(ctx, proposalPrefix(pipeline, phase))
+	if err != nil {
+		return nil, fmt.Errorf("listing proposals: %w", err)
+	}
+
+	for _, proposal := range proposals {
+		if pending != "" && proposal.Digest == pending {
+			continue
+		}
+
+		if dryRun {
+			pruned = append(pruned, proposal.Branch)
+			continue
+		}
+
+		comment := "The phase is up to date so this proposal no longer represents a pending change."
+		if pending != "" {
+			comment = fmt.Sprintf("The pending change for this phase is now %s.", pending)
+		}
+
+		if err := g.closeProposal(ctx, pipeline, phase, proposal, comment); err != nil {
+			return pruned, err
+		}
+
+		pruned = append(pruned, proposal.Branch)
+	}
+
+	return pruned, nil
+}
+
+// closeProposal closes the proposal with an explanatory comment and deletes its branch.
+func (g *Source[A]) closeProposal(ctx context.Context, pipeline, phase core.Metadata, proposal *Proposal, reason string) error {
+	if err := g.proposer.CloseProposal(ctx, proposal, CloseOption{
+		Comment: fmt.Sprintf("Closed by glu: %s", reason),
+	}); err != nil {
+		return fmt.Errorf("closing proposal %q: %w", proposal.Branch, err)
+	}
+
+	if err := g.repo.DeleteBranch(ctx, proposal.Branch); err != nil {
+		return fmt.Errorf("deleting proposal branch %q: %w", proposal.Branch, err)
+	}
+
+	slog.Info("proposal closed", "name", phase.Name, "branch", proposal.Branch, "reason", reason)
+
+	g.publishProposal(ctx, events.ProposalClosed, pipeline, phase, proposal, map[string]string{
+		"reason": reason,
+	})
+
+	return nil
+}
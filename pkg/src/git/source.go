@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"log/slog"
 	"path"
+	"strings"
 	"sync"
 
 	"github.com/get-glu/glu/internal/git"
 	"github.com/get-glu/glu/pkg/containers"
 	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/events"
 	"github.com/get-glu/glu/pkg/fs"
 	"github.com/get-glu/glu/pkg/phases"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -18,7 +20,10 @@ import (
 
 var ErrProposalNotFound = errors.New("proposal not found")
 
-var _ phases.Source[Resource] = (*Source[Resource])(nil)
+var (
+	_ phases.Source[Resource]     = (*Source[Resource])(nil)
+	_ phases.PlanSource[Resource] = (*Source[Resource])(nil)
+)
 
 type Resource interface {
 	core.Resource
@@ -28,14 +33,25 @@ type Resource interface {
 
 type Proposer interface {
 	GetCurrentProposal(_ context.Context, baseBranch, branchPrefix string) (*Proposal, error)
+	// ListProposals returns every open proposal with a branch beneath branchPrefix regardless of its base
+	ListProposals(_ context.Context, branchPrefix string) ([]*Proposal, error)
 	CreateProposal(context.Context, *Proposal, ProposalOption) error
-	MergeProposal(context.Context, *Proposal) error
-	CloseProposal(context.Context, *Proposal) error
+	MergeProposal(context.Context, *Proposal, MergeOption) error
+	CloseProposal(context.Context, *Proposal, CloseOption) error
+}
+
+// CloseOption configures how a proposal is closed.
+type CloseOption struct {
+	// Comment (when non-empty) is left on the proposal explaining why it was closed
+	Comment string
 }
 
 // Proposal contains the fields necessary to propose a resource update
 // to a Repository.
 type Proposal struct {
+	// Number is the SCM specific identifier for the proposal (e.g. PR number)
+	Number       int
+	URL          string
 	BaseRevision string
 	BaseBranch   string
 	Branch       string
@@ -52,6 +68,13 @@ type Source[A Resource] struct {
 	proposer        Proposer
 	proposeChange   bool
 	proposalOptions ProposalOption
+
+	watchOnce sync.Once
+	watcher   *watcher
+
+	// history caches the decoded history of each pipeline phase by the head it was walked from
+	historyMu sync.Mutex
+	history   map[string]historyEntry
 }
 
 func (s Source[A]) Type() string {
@@ -60,6 +83,10 @@ func (s Source[A]) Type() string {
 
 type ProposalOption struct {
 	Labels []string
+	// Reviewers are the SCM usernames requested to review the proposal
+	Reviewers []string
+	// AutoMerge (when non-nil) merges proposals once they satisfy the provided conditions
+	AutoMerge *AutoMerge
 }
 
 // ProposeChanges configures the phase to propose the change (via PR or MR)
@@ -137,6 +164,16 @@ func (g *Source[A]) Update(ctx context.Context, pipeline, phase core.Metadata, f
 		return fmt.Errorf("fetching upstream during update: %w", err)
 	}
 
+	fromDigest, err := from.Digest()
+	if err != nil {
+		return err
+	}
+
+	digest, err := to.Digest()
+	if err != nil {
+		return err
+	}
+
 	message := fmt.Sprintf("Update %s", phase.Name)
 	if m, ok := core.Resource(to).(commitMessage[A]); ok {
 		message, err = m.CommitMessage(phase, from)
@@ -150,7 +187,12 @@ func (g *Source[A]) Update(ctx context.Context, pipeline, phase core.Metadata, f
 			return "", err
 		}
 
-		return message, nil
+		return withTrailers(ctx, message,
+			"Glu-Pipeline", pipeline.Name,
+			"Glu-Phase", phase.Name,
+			"Glu-From-Digest", fromDigest,
+			"Glu-To-Digest", digest,
+		), nil
 	}
 
 	// use the target resources branch if it implementes an override
@@ -160,7 +202,7 @@ func (g *Source[A]) Update(ctx context.Context, pipeline, phase core.Metadata, f
 	}
 
 	if !g.proposeChange {
-		if _, err := g.repo.UpdateAndPush(ctx, update, git.WithBranch(baseBranch)); err != nil {
+		if _, err := g.repo.UpdateAndPush(ctx, update, git.WithBranch(baseBranch), withActor(ctx)); err != nil {
 			if errors.Is(err, git.ErrEmptyCommit) {
 				slog.Info("promotion produced no changes")
 
@@ -182,14 +224,9 @@ func (g *Source[A]) Update(ctx context.Context, pipeline, phase core.Metadata, f
 		return fmt.Errorf("resolving base branch %q: %w", baseBranch, err)
 	}
 
-	digest, err := to.Digest()
-	if err != nil {
-		return err
-	}
-
 	// create branch name and check if this phase, resource and state has previously been observed
 	var (
-		branchPrefix = fmt.Sprintf("glu/%s/%s", pipeline.Name, phase.Name)
+		branchPrefix = proposalPrefix(pipeline, phase)
 		branch       = path.Join(branchPrefix, digest)
 	)
 
@@ -207,22 +244,33 @@ func (g *Source[A]) Update(ctx context.Context, pipeline, phase core.Metadata, f
 		slog.Debug("proposal not found")
 	}
 
-	options := []containers.Option[git.ViewUpdateOptions]{git.WithBranch(branch)}
+	if proposal != nil && proposal.Digest != digest {
+		// the existing proposal is for a different state and has been superseded
+		if err := g.closeProposal(ctx, pipeline, phase, proposal, fmt.Sprintf("Superseded by a proposal for %s.", digest)); err != nil {
+			return err
+		}
+
+		proposal = nil
+	}
+
+	options := []containers.Option[git.ViewUpdateOptions]{git.WithBranch(branch), withActor(ctx)}
 	if proposal != nil {
 		// there is an existing proposal
 		slog.Debug("proposal found", "base", proposal.BaseBranch, "base_revision", proposal.BaseRevision)
-		if proposal.BaseRevision != baseRev.String() {
-			// we're potentially going to force update the branch to move the base
-			options = append(options, git.WithForce)
-		} else {
-			if proposal.Digest == digest {
-				// nothing has changed since the last promotion and proposals
-				slog.Debug("skipping proposal", "reason", "AlreadyExistsAndUpToDate")
-
-				return nil
+		if proposal.BaseRevision == baseRev.String() {
+			// nothing has changed since the last promotion and proposals
+			if g.proposalOptions.AutoMerge != nil {
+				return g.autoMerge(ctx, pipeline, phase, proposal, update)
 			}
+
+			slog.Debug("skipping proposal", "reason", "AlreadyExistsAndUpToDate")
+
+			return nil
 		}
 
+		// we're potentially going to force update the branch to move the base
+		options = append(options, git.WithForce)
+
 		if _, err := g.repo.UpdateAndPush(ctx, update, options...); err != nil {
 			if errors.Is(err, git.ErrEmptyCommit) {
 				slog.Debug("skipping proposal", "reason", "UpdateProducedNoChange")
@@ -246,11 +294,6 @@ func (g *Source[A]) Update(ctx context.Context, pipeline, phase core.Metadata, f
 		return err
 	}
 
-	fromDigest, err := from.Digest()
-	if err != nil {
-		return err
-	}
-
 	title := message
 	if p, ok := core.Resource(to).(proposalTitle[A]); ok {
 		title, err = p.ProposalTitle(phase, from)
@@ -274,6 +317,7 @@ func (g *Source[A]) Update(ctx context.Context, pipeline, phase core.Metadata, f
 		BaseRevision: baseRev.String(),
 		BaseBranch:   baseBranch,
 		Branch:       branch,
+		Digest:       digest,
 		Title:        title,
 		Body:         body,
 	}
@@ -282,5 +326,66 @@ func (g *Source[A]) Update(ctx context.Context, pipeline, phase core.Metadata, f
 		return err
 	}
 
+	if g.proposalOptions.AutoMerge != nil {
+		if merger, ok := g.nativeAutoMerger(); ok {
+			if err := g.enableAutoMerge(ctx, merger, phase, proposal); err != nil {
+				return err
+			}
+		}
+	}
+
+	g.publishProposal(ctx, events.ProposalOpened, pipeline, phase, proposal, map[string]string{
+		"from_digest": fromDigest,
+	})
+
 	return nil
 }
+
+// Plan returns a unified diff of the changes which writing the resource to would
+// make to the target branch, without committing or pushing them.
+func (g *Source[A]) Plan(ctx context.Context, _, phase core.Metadata, _, to A) (string, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	// perform an initial fetch to ensure we're up to date
+	if err := g.repo.Fetch(ctx); err != nil {
+		return "", fmt.Errorf("fetching upstream during plan: %w", err)
+	}
+
+	// use the target resources branch if it implementes an override
+	baseBranch := g.repo.DefaultBranch()
+	if branched, ok := core.Resource(to).(Branched); ok {
+		baseBranch = branched.Branch()
+	}
+
+	return g.repo.Diff(ctx, func(fs fs.Filesystem) error {
+		return to.WriteTo(ctx, phase, fs)
+	}, git.WithBranch(baseBranch))
+}
+
+// withActor authors commits as the core.Actor carried by ctx (if any).
+func withActor(ctx context.Context) containers.Option[git.ViewUpdateOptions] {
+	actor, _ := core.ActorFromContext(ctx)
+	return git.WithAuthor(actor.Name, actor.Email)
+}
+
+// withTrailers appends a git trailer to message for each non-empty key/value pair in kv.
+// When the context carries a core.Actor with a trigger, then a Glu-Trigger trailer is also added.
+func withTrailers(ctx context.Context, message string, kv ...string) string {
+	var trailers []string
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			trailers = append(trailers, kv[i]+": "+kv[i+1])
+		}
+	}
+
+	if actor, ok := core.ActorFromContext(ctx); ok && actor.Trigger != "" {
+		trailers = append(trailers, "Glu-Trigger: "+actor.Trigger)
+	}
+
+	if len(trailers) == 0 {
+		return message
+	}
+
+	return strings.TrimRight(message, "\n") + "\n\n" + strings.Join(trailers, "\n")
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/get-glu/glu/internal/git"
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/phases"
+)
+
+var (
+	_ phases.WatchableSource[Resource] = (*Source[Resource])(nil)
+	_ git.Subscriber                   = (*watcher)(nil)
+)
+
+// watcher subscribes to the sources repository and calls the registered
+// functions each time the head of the watched branch moves.
+type watcher struct {
+	branch string
+
+	mu    sync.Mutex
+	heads map[string]string
+	fns   []func(context.Context)
+}
+
+// Watch registers fn to be called each time the default branch of the underlying
+// repository changes (either via fetch or a local update).
+// As sources can be shared across phases, this signals that the state of the phase may
+// have changed, not that it definitely has.
+func (g *Source[A]) Watch(_, _ core.Metadata, fn func(context.Context)) {
+	g.watchOnce.Do(func() {
+		g.watcher = &watcher{branch: g.repo.DefaultBranch(), heads: map[string]string{}}
+
+		// seed the current head so that the first change observed is not mistaken
+		// for the initial state (an absent branch is seeded as empty)
+		if head, err := g.repo.Resolve(g.watcher.branch); err == nil {
+			g.watcher.heads[g.watcher.branch] = head.String()
+		}
+
+		g.repo.Subscribe(g.watcher)
+	})
+
+	g.watcher.mu.Lock()
+	defer g.watcher.mu.Unlock()
+
+	g.watcher.fns = append(g.watcher.fns, fn)
+}
+
+func (w *watcher) Branches() []string {
+	return []string{w.branch}
+}
+
+// Notify is called by the repository while holding its lock and so the
+// registered functions must not call back into the repository.
+func (w *watcher) Notify(ctx context.Context, refs map[string]string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(refs) == 0 || maps.Equal(w.heads, refs) {
+		return nil
+	}
+
+	w.heads = refs
+
+	for _, fn := range w.fns {
+		fn(ctx)
+	}
+
+	return nil
+}
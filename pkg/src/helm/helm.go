@@ -0,0 +1,52 @@
+package helm
+
+import (
+	"context"
+
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/phases"
+)
+
+var _ phases.Source[Resource] = (*Source[Resource])(nil)
+
+type Resource interface {
+	core.Resource
+	ReadFromHelmChart(version, digest, appVersion string) error
+}
+
+// Chart is a resolved version of a Helm chart.
+type Chart struct {
+	Name       string
+	Version    string
+	AppVersion string
+	// Digest is the digest of the chart archive (HTTP repositories)
+	// or of its manifest (OCI registries)
+	Digest string
+}
+
+type Resolver interface {
+	Resolve(_ context.Context) (Chart, error)
+}
+
+type Source[R Resource] struct {
+	resolver Resolver
+}
+
+func New[R Resource](resolver Resolver) *Source[R] {
+	return &Source[R]{
+		resolver: resolver,
+	}
+}
+
+func (s *Source[R]) Type() string {
+	return "helm"
+}
+
+func (s *Source[R]) View(ctx context.Context, _, _ core.Metadata, r R) error {
+	chart, err := s.resolver.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.ReadFromHelmChart(chart.Version, chart.Digest, chart.AppVersion)
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/get-glu/glu/pkg/containers"
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/phases"
+)
+
+var _ phases.Source[Resource] = (*Source[Resource])(nil)
+
+// maxResponseSize is the largest response body (10MiB) an Endpoint will read.
+const maxResponseSize = 10 << 20
+
+type Resource interface {
+	core.Resource
+	ReadFromHTTPResponse(*Response) error
+}
+
+// Response is a successful response from an HTTP endpoint.
+// The body is shared between reads of a cached response and must not be modified.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type Fetcher interface {
+	Fetch(_ context.Context) (*Response, error)
+}
+
+type Source[R Resource] struct {
+	fetcher Fetcher
+}
+
+func New[R Resource](fetcher Fetcher) *Source[R] {
+	return &Source[R]{
+		fetcher: fetcher,
+	}
+}
+
+func (s *Source[R]) Type() string {
+	return "http"
+}
+
+func (s *Source[R]) View(ctx context.Context, _, _ core.Metadata, r R) error {
+	resp, err := s.fetcher.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.ReadFromHTTPResponse(resp)
+}
+
+var _ Fetcher = (*Endpoint)(nil)
+
+// Endpoint fetches the current state of a resource from a URL.
+// It caches the last response and revalidates it using its ETag (If-None-Match),
+// so unchanged state is not transferred again.
+type Endpoint struct {
+	url    string
+	client *http.Client
+	header http.Header
+
+	mu     sync.Mutex
+	cached *Response
+}
+
+// NewEndpoint returns an Endpoint which performs GET requests against the provided URL.
+func NewEndpoint(url string, opts ...containers.Option[Endpoint]) *Endpoint {
+	e := &Endpoint{
+		url:    url,
+		client: http.DefaultClient,
+		header: http.Header{},
+	}
+
+	containers.ApplyAll(e, opts...)
+
+	return e
+}
+
+// WithHTTPClient overrides the client used to perform requests (e.g. one carrying credentials).
+func WithHTTPClient(client *http.Client) containers.Option[Endpoint] {
+	return func(e *Endpoint) {
+		e.client = client
+	}
+}
+
+// WithHeader adds a header sent with every request (e.g. Accept: application/json).
+func WithHeader(key, value string) containers.Option[Endpoint] {
+	return func(e *Endpoint) {
+		e.header.Add(key, value)
+	}
+}
+
+// Fetch performs a GET request against the endpoint.
+// When the endpoint reports the previously fetched response as not modified,
+// then the cached response is returned.
+func (e *Endpoint) Fetch(ctx context.Context) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = e.header.Clone()
+
+	e.mu.Lock()
+	cached := e.cached
+	e.mu.Unlock()
+
+	if etag := cachedETag(cached); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return cached, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", e.url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", e.url, err)
+	}
+
+	if len(body) > maxResponseSize {
+		return nil, fmt.Errorf("reading %s: response body exceeds %d bytes", e.url, maxResponseSize)
+	}
+
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}
+
+	e.mu.Lock()
+	e.cached = response
+	e.mu.Unlock()
+
+	return response, nil
+}
+
+func cachedETag(resp *Response) string {
+	if resp == nil {
+		return ""
+	}
+
+	return resp.Header.Get("ETag")
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEndpointFetch(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		body     = `{"version":"1.0.0"}`
+		etag     = `"v1"`
+		received []http.Header
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		received = append(received, r.Header.Clone())
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	endpoint := NewEndpoint(srv.URL, WithHTTPClient(srv.Client()), WithHeader("Accept", "application/json"))
+
+	first, err := endpoint.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.StatusCode != http.StatusOK || string(first.Body) != body || first.Header.Get("ETag") != etag {
+		t.Errorf("unexpected first response %d %q %v", first.StatusCode, first.Body, first.Header)
+	}
+
+	second, err := endpoint.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second != first {
+		t.Error("expected the cached response to be returned when not modified")
+	}
+
+	// the upstream state changes
+	mu.Lock()
+	body, etag = `{"version":"1.1.0"}`, `"v2"`
+	mu.Unlock()
+
+	third, err := endpoint.Fetch(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(third.Body) != body || third.Header.Get("ETag") != `"v2"` {
+		t.Errorf("unexpected third response %q %v", third.Body, third.Header)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(received) != 3 {
+		t.Fatalf("expected 3 requests, found %d", len(received))
+	}
+
+	for i, expected := range []string{"", `"v1"`, `"v1"`} {
+		if found := received[i].Get("If-None-Match"); found != expected {
+			t.Errorf("request %d: expected If-None-Match %q, found %q", i, expected, found)
+		}
+
+		if accept := received[i].Get("Accept"); accept != "application/json" {
+			t.Errorf("request %d: expected Accept header, found %q", i, accept)
+		}
+	}
+}
+
+func TestEndpointFetch_Errors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "not found",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.NotFound(w, r)
+			},
+		},
+		{
+			name: "server error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		},
+		{
+			// a not modified response is unexpected without a cached response to revalidate
+			name: "not modified without cache",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotModified)
+			},
+		},
+		{
+			name: "body too large",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(strings.Repeat("a", maxResponseSize+1)))
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(test.handler)
+			t.Cleanup(srv.Close)
+
+			resp, err := NewEndpoint(srv.URL, WithHTTPClient(srv.Client())).Fetch(context.Background())
+			if err == nil {
+				t.Fatalf("expected error, found response %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestEndpointFetch_ErrorAfterCache(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		failed bool
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failed {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(srv.Close)
+
+	endpoint := NewEndpoint(srv.URL, WithHTTPClient(srv.Client()))
+	if _, err := endpoint.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	failed = true
+	mu.Unlock()
+
+	// a cached response is not returned in place of an error
+	if _, err := endpoint.Fetch(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
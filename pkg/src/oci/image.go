@@ -0,0 +1,127 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/get-glu/glu/pkg/core"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerConfig       = "application/vnd.docker.container.image.v1+json"
+)
+
+// ImageResource is an optional interface for a Resource which reads the fetched image
+// content (manifests, annotations and config) for the resolved descriptor.
+// When implemented, it is called instead of ReadFromOCIDescriptor.
+// It requires the sources Resolver to also implement Fetcher.
+type ImageResource interface {
+	ReadFromOCIImage(Image) error
+}
+
+// Fetcher is an optional interface for a Resolver which can fetch the content of a descriptor.
+type Fetcher interface {
+	Fetch(_ context.Context, desc v1.Descriptor) ([]byte, error)
+}
+
+// Image is the content referenced by a resolved descriptor.
+type Image struct {
+	// Tag is the tag the descriptor was resolved from (if known)
+	Tag        string
+	Descriptor v1.Descriptor
+	// Index is set when the descriptor refers to an image index (multi-platform image)
+	Index *v1.Index
+	// Manifests contains the single image manifest or, for an index, the manifest for each platform
+	Manifests []Manifest
+}
+
+// Manifest is a fetched image manifest along with its config blob.
+type Manifest struct {
+	// Descriptor describes the manifest (including its platform when part of an index)
+	Descriptor v1.Descriptor
+	Manifest   v1.Manifest
+	// Config is the decoded image config (labels are found at Config.Config.Labels)
+	// It is nil when the config blob is not an image config (e.g. OCI artifacts)
+	Config *v1.Image
+	// RawConfig is the undecoded config blob
+	RawConfig []byte
+}
+
+func (s *Source[R]) readImage(ctx context.Context, desc v1.Descriptor, r ImageResource) error {
+	fetcher, ok := s.resolver.(Fetcher)
+	if !ok {
+		return fmt.Errorf("reading image content: resolver cannot fetch: %w", core.ErrNotSupported)
+	}
+
+	image := Image{
+		Tag:        desc.Annotations[v1.AnnotationRefName],
+		Descriptor: desc,
+	}
+
+	switch desc.MediaType {
+	case v1.MediaTypeImageIndex, mediaTypeDockerManifestList:
+		data, err := fetcher.Fetch(ctx, desc)
+		if err != nil {
+			return err
+		}
+
+		image.Index = &v1.Index{}
+		if err := json.Unmarshal(data, image.Index); err != nil {
+			return fmt.Errorf("decoding image index: %w", err)
+		}
+
+		for _, desc := range image.Index.Manifests {
+			if desc.MediaType != v1.MediaTypeImageManifest && desc.MediaType != mediaTypeDockerManifest {
+				// skip nested indexes and other artifacts
+				continue
+			}
+
+			manifest, err := fetchManifest(ctx, fetcher, desc)
+			if err != nil {
+				return err
+			}
+
+			image.Manifests = append(image.Manifests, manifest)
+		}
+	default:
+		manifest, err := fetchManifest(ctx, fetcher, desc)
+		if err != nil {
+			return err
+		}
+
+		image.Manifests = append(image.Manifests, manifest)
+	}
+
+	return r.ReadFromOCIImage(image)
+}
+
+func fetchManifest(ctx context.Context, fetcher Fetcher, desc v1.Descriptor) (Manifest, error) {
+	data, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest := Manifest{Descriptor: desc}
+	if err := json.Unmarshal(data, &manifest.Manifest); err != nil {
+		return Manifest{}, fmt.Errorf("decoding manifest %s: %w", desc.Digest, err)
+	}
+
+	manifest.RawConfig, err = fetcher.Fetch(ctx, manifest.Manifest.Config)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	switch manifest.Manifest.Config.MediaType {
+	case v1.MediaTypeImageConfig, mediaTypeDockerConfig:
+		manifest.Config = &v1.Image{}
+		if err := json.Unmarshal(manifest.RawConfig, manifest.Config); err != nil {
+			return Manifest{}, fmt.Errorf("decoding config %s: %w", manifest.Manifest.Config.Digest, err)
+		}
+	}
+
+	return manifest, nil
+}
@@ -2,30 +2,53 @@ package oci
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/get-glu/glu/pkg/core"
 	"github.com/get-glu/glu/pkg/phases"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
-var _ phases.Source[Resource] = (*Source[Resource])(nil)
+var (
+	_ phases.Source[Resource]           = (*Source[Resource])(nil)
+	_ phases.VerifiableSource[Resource] = (*Source[Resource])(nil)
+)
 
 type Resource interface {
 	core.Resource
 	ReadFromOCIDescriptor(v1.Descriptor) error
 }
 
+// TaggedResource is an optional interface for a Resource which also reads the tag
+// the descriptor was resolved from. When implemented, it is called instead of ReadFromOCIDescriptor.
+type TaggedResource interface {
+	ReadFromOCITag(tag string, desc v1.Descriptor) error
+}
+
 type Resolver interface {
 	Resolve(_ context.Context) (v1.Descriptor, error)
 }
 
+// Verifier is an optional interface for a Resolver which verifies the provenance
+// (e.g. signatures) of resolved descriptors.
+// Verify returns nil when the resolver is not configured to verify.
+type Verifier interface {
+	Verify(_ context.Context, desc v1.Descriptor) (*core.Verification, error)
+}
+
 type Source[R Resource] struct {
 	resolver Resolver
+
+	mu sync.Mutex
+	// verified caches successful verifications by digest
+	verified map[string]*core.Verification
 }
 
 func New[R Resource](resolver Resolver) *Source[R] {
 	return &Source[R]{
 		resolver: resolver,
+		verified: map[string]*core.Verification{},
 	}
 }
 
@@ -33,11 +56,74 @@ func (s *Source[R]) Type() string {
 	return "oci"
 }
 
+// View resolves the current descriptor and reads it into the resource.
+// When the resolver verifies descriptors, then unverified descriptors are
+// not read and an error wrapping core.ErrUnverified is returned.
 func (s *Source[R]) View(ctx context.Context, _, _ core.Metadata, r R) error {
 	desc, err := s.resolver.Resolve(ctx)
 	if err != nil {
 		return err
 	}
 
+	verification, err := s.verify(ctx, desc)
+	if err != nil {
+		return err
+	}
+
+	if verification != nil && !verification.Verified {
+		return fmt.Errorf("digest %s: %s: %w", desc.Digest, verification.Reason, core.ErrUnverified)
+	}
+
+	if image, ok := core.Resource(r).(ImageResource); ok {
+		return s.readImage(ctx, desc, image)
+	}
+
+	if tagged, ok := core.Resource(r).(TaggedResource); ok {
+		return tagged.ReadFromOCITag(desc.Annotations[v1.AnnotationRefName], desc)
+	}
+
 	return r.ReadFromOCIDescriptor(desc)
 }
+
+// Verification resolves the current descriptor and returns the outcome of verifying it.
+// It returns nil when the resolver does not verify descriptors.
+func (s *Source[R]) Verification(ctx context.Context, _, _ core.Metadata) (*core.Verification, error) {
+	desc, err := s.resolver.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.verify(ctx, desc)
+}
+
+func (s *Source[R]) verify(ctx context.Context, desc v1.Descriptor) (*core.Verification, error) {
+	verifier, ok := s.resolver.(Verifier)
+	if !ok {
+		return nil, nil
+	}
+
+	digest := desc.Digest.String()
+
+	s.mu.Lock()
+	verification, ok := s.verified[digest]
+	s.mu.Unlock()
+
+	if ok {
+		return verification, nil
+	}
+
+	verification, err := verifier.Verify(ctx, desc)
+	if err != nil {
+		return nil, fmt.Errorf("verifying %s: %w", digest, err)
+	}
+
+	// only successful verifications are cached as signatures
+	// may be pushed after an image is first observed
+	if verification != nil && verification.Verified {
+		s.mu.Lock()
+		s.verified[digest] = verification
+		s.mu.Unlock()
+	}
+
+	return verification, nil
+}
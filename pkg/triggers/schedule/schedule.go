@@ -2,6 +2,7 @@ package schedule
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"time"
 
@@ -35,6 +36,8 @@ func New(opts ...containers.Option[Trigger]) *Trigger {
 func (t *Trigger) Run(ctx context.Context, p glu.Pipelines) {
 	slog.Debug("starting promotion schedule", "interval", t.interval)
 
+	ctx = core.WithActor(ctx, core.Actor{Name: "glu schedule", Trigger: "schedule"})
+
 	ticker := time.NewTicker(t.interval)
 	for {
 		select {
@@ -44,6 +47,16 @@ func (t *Trigger) Run(ctx context.Context, p glu.Pipelines) {
 			for _, pipeline := range p.Pipelines() {
 				for phase := range pipeline.Phases(t.options...) {
 					if err := phase.Promote(ctx); err != nil {
+						if errors.Is(err, core.ErrPromotionDenied) {
+							slog.Warn("promotion denied", "name", phase.Metadata().Name, "reason", err)
+							continue
+						}
+
+						if errors.Is(err, core.ErrPromotionHeld) {
+							slog.Info("promotion held", "name", phase.Metadata().Name, "reason", err)
+							continue
+						}
+
 						slog.Error("promoting resource", "name", phase.Metadata().Name, "error", err)
 					}
 				}
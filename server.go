@@ -4,8 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/get-glu/glu/pkg/approvals"
+	"github.com/get-glu/glu/pkg/auth"
+	"github.com/get-glu/glu/pkg/containers"
 	"github.com/get-glu/glu/pkg/core"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -13,20 +21,60 @@ import (
 )
 
 type Server struct {
-	system *System
-	router *chi.Mux
+	system   *System
+	router   *chi.Mux
+	basePath string
+
+	authenticators []auth.Authenticator
+	authorizer     auth.Authorizer
+
+	// allowedOrigins permits cross-origin requests (none permits only same-origin requests)
+	allowedOrigins   []string
+	allowCredentials bool
 }
 
-func newServer(system *System) *Server {
+func newServer(system *System, opts ...containers.Option[Server]) *Server {
 	s := &Server{
 		system: system,
 		router: chi.NewRouter(),
 	}
 
+	containers.ApplyAll(s, opts...)
+
 	s.setupRoutes()
 	return s
 }
 
+// withBasePath mounts all routes beneath the provided path prefix.
+func withBasePath(path string) containers.Option[Server] {
+	return func(s *Server) {
+		s.basePath = path
+	}
+}
+
+// withAuthentication requires API requests to be authenticated
+// by one of the provided authenticators.
+func withAuthentication(authenticators ...auth.Authenticator) containers.Option[Server] {
+	return func(s *Server) {
+		s.authenticators = authenticators
+	}
+}
+
+// withCORS permits cross-origin requests from the provided origins.
+func withCORS(origins []string, credentials bool) containers.Option[Server] {
+	return func(s *Server) {
+		s.allowedOrigins = origins
+		s.allowCredentials = credentials
+	}
+}
+
+// withAuthorizer restricts mutating phase actions using the provided authorizer.
+func withAuthorizer(authorizer auth.Authorizer) containers.Option[Server] {
+	return func(s *Server) {
+		s.authorizer = authorizer
+	}
+}
+
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.router.ServeHTTP(w, r)
 }
@@ -34,27 +82,67 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func (s *Server) setupRoutes() {
 	s.router.Use(middleware.Logger)
 	s.router.Use(middleware.Recoverer)
-	s.router.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"http://*", "https://*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"*"},
-		AllowCredentials: false,
-		MaxAge:           300,
-	}))
+	if len(s.allowedOrigins) > 0 {
+		s.router.Use(cors.Handler(cors.Options{
+			AllowedOrigins:   s.allowedOrigins,
+			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+			AllowedHeaders:   []string{"*"},
+			AllowCredentials: s.allowCredentials,
+			MaxAge:           300,
+		}))
+	}
 	s.router.Use(middleware.SetHeader("Content-Type", "application/json"))
 	s.router.Use(middleware.StripSlashes)
 
-	s.router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
+	prefix := s.basePath
+	if prefix == "" {
+		prefix = "/"
+	}
+
+	s.router.Route(prefix, func(r chi.Router) {
+		r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// API routes
+		r.Route("/api/v1", func(r chi.Router) {
+			if len(s.authenticators) > 0 {
+				r.Use(auth.Middleware(s.authenticators...))
+			}
+
+			r.Use(withActor)
+
+			r.Get("/", s.getRoot)
+			r.Get("/pipelines", s.listPipelines)
+			r.Get("/pipelines/{pipeline}", s.getPipeline)
+			r.Get("/pipelines/{pipeline}/phases/{phase}", s.getPhase)
+			r.Post("/pipelines/{pipeline}/phases/{phase}/promote", s.promotePhase)
+			r.Get("/pipelines/{pipeline}/phases/{phase}/history", s.phaseHistory)
+			r.Post("/pipelines/{pipeline}/phases/{phase}/rollback", s.rollbackPhase)
+			r.Post("/pipelines/{pipeline}/phases/{phase}/pin", s.pinPhase)
+			r.Delete("/pipelines/{pipeline}/phases/{phase}/pin", s.unpinPhase)
+			r.Get("/events", s.streamEvents)
+			r.Get("/approvals", s.listApprovals)
+			r.Get("/approvals/{approval}", s.getApproval)
+			r.Post("/approvals/{approval}/approve", s.decideApproval(approvals.Approve))
+			r.Post("/approvals/{approval}/reject", s.decideApproval(approvals.Reject))
+		})
 	})
+}
 
-	// API routes
-	s.router.Route("/api/v1", func(r chi.Router) {
-		r.Get("/", s.getRoot)
-		r.Get("/pipelines", s.listPipelines)
-		r.Get("/pipelines/{pipeline}", s.getPipeline)
-		r.Get("/pipelines/{pipeline}/phases/{phase}", s.getPhase)
-		r.Post("/pipelines/{pipeline}/phases/{phase}/promote", s.promotePhase)
+// withActor attributes operations performed during the request to the
+// authenticated identity (when present).
+func withActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := core.Actor{Trigger: "api"}
+		if id, ok := auth.IdentityFromContext(r.Context()); ok {
+			actor.Name, actor.Email = id.Name, id.Email
+			if actor.Name == "" {
+				actor.Name = id.Subject
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(core.WithActor(r.Context(), actor)))
 	})
 }
 
@@ -79,22 +167,25 @@ type pipelineResponse struct {
 
 type phaseResponse struct {
 	Name       string            `json:"name"`
-	DependsOn  string            `json:"depends_on,omitempty"`
+	DependsOn  []string          `json:"depends_on,omitempty"`
 	SourceType string            `json:"source_type,omitempty"`
 	Labels     map[string]string `json:"labels,omitempty"`
 	Value      interface{}       `json:"value,omitempty"`
+	Pinned     *core.Pin         `json:"pinned,omitempty"`
+	Gates      []core.GateResult `json:"gates,omitempty"`
+	Proposals  []core.Proposal   `json:"proposals,omitempty"`
+	// Verification is the outcome of verifying the current value, when configured
+	Verification *core.Verification `json:"verification,omitempty"`
 }
 
-func (s *Server) createPhaseResponse(phase core.Phase, dependencies map[core.Phase]core.Phase) phaseResponse {
+func (s *Server) createPhaseResponse(phase core.Phase, dependencies map[core.Phase][]core.Phase) phaseResponse {
 	var (
-		dependsOn string
+		dependsOn []string
 		labels    map[string]string
 	)
 
-	if dependencies != nil {
-		if d, ok := dependencies[phase]; ok && d != nil {
-			dependsOn = d.Metadata().Name
-		}
+	for _, d := range dependencies[phase] {
+		dependsOn = append(dependsOn, d.Metadata().Name)
 	}
 
 	if phase.Metadata().Labels != nil {
@@ -109,6 +200,67 @@ func (s *Server) createPhaseResponse(phase core.Phase, dependencies map[core.Pha
 	}
 }
 
+// pinned returns the current pin for the phase (if supported and pinned).
+func pinned(ctx context.Context, phase core.Phase) (*core.Pin, error) {
+	pinnable, ok := phase.(core.PinnablePhase)
+	if !ok {
+		return nil, nil
+	}
+
+	pin, err := pinnable.Pinned(ctx)
+	if err != nil && !errors.Is(err, core.ErrNotSupported) {
+		return nil, err
+	}
+
+	return pin, nil
+}
+
+// value returns the phases current value.
+// Unverified values are omitted rather than failing the response,
+// as the reason is reported by the phases verification.
+func value(ctx context.Context, phase core.Phase) (any, error) {
+	v, err := phase.Get(ctx)
+	if err != nil {
+		if errors.Is(err, core.ErrUnverified) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// verification returns the outcome of verifying the phases current value (if supported and configured).
+func verification(ctx context.Context, phase core.Phase) (*core.Verification, error) {
+	verifiable, ok := phase.(core.VerifiablePhase)
+	if !ok {
+		return nil, nil
+	}
+
+	verification, err := verifiable.Verification(ctx)
+	if err != nil && !errors.Is(err, core.ErrNotSupported) {
+		return nil, err
+	}
+
+	return verification, nil
+}
+
+// proposals returns the open proposals for the phase (if supported).
+func proposals(ctx context.Context, phase core.Phase) ([]core.Proposal, error) {
+	proposer, ok := phase.(core.ProposalPhase)
+	if !ok {
+		return nil, nil
+	}
+
+	proposals, err := proposer.Proposals(ctx)
+	if err != nil && !errors.Is(err, core.ErrNotSupported) {
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
 func (s *Server) createPipelineResponse(ctx context.Context, pipeline core.Pipeline) (pipelineResponse, error) {
 	dependencies := pipeline.Dependencies()
 	phases := make([]phaseResponse, 0)
@@ -116,11 +268,13 @@ func (s *Server) createPipelineResponse(ctx context.Context, pipeline core.Pipel
 	for phase := range pipeline.Phases() {
 		response := s.createPhaseResponse(phase, dependencies)
 
-		v, err := phase.Get(ctx)
+		// verification, pins and proposals can each require calls to remote services
+		// and so are only included when getting a single phase
+		var err error
+		response.Value, err = value(ctx, phase)
 		if err != nil {
 			return pipelineResponse{}, err
 		}
-		response.Value = v
 
 		phases = append(phases, response)
 	}
@@ -180,11 +334,13 @@ func (s *Server) getPipeline(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-func (s *Server) getPhase(w http.ResponseWriter, r *http.Request) {
+// phaseFromRequest locates the pipeline and phase identified by the requests URL parameters.
+// It writes an appropriate error response and returns false if either cannot be found.
+func (s *Server) phaseFromRequest(w http.ResponseWriter, r *http.Request) (core.Pipeline, core.Phase, bool) {
 	pipeline, err := s.system.GetPipeline(chi.URLParam(r, "pipeline"))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
-		return
+		return nil, nil, false
 	}
 
 	phaseName := chi.URLParam(r, "phase")
@@ -196,17 +352,80 @@ func (s *Server) getPhase(w http.ResponseWriter, r *http.Request) {
 		}
 
 		http.Error(w, err.Error(), status)
+		return nil, nil, false
+	}
+
+	return pipeline, phase, true
+}
+
+// authorize checks that the requests identity may perform the action on the phase.
+// It writes an appropriate error response and returns false if it may not.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, action auth.Action, pipeline, phase core.Metadata) bool {
+	if s.authorizer == nil {
+		return true
+	}
+
+	id, _ := auth.IdentityFromContext(r.Context())
+	if err := s.authorizer.Authorize(r.Context(), auth.Request{
+		Identity: id,
+		Action:   action,
+		Pipeline: pipeline,
+		Phase:    phase,
+	}); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, auth.ErrForbidden) {
+			status = http.StatusForbidden
+		}
+
+		http.Error(w, err.Error(), status)
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) getPhase(w http.ResponseWriter, r *http.Request) {
+	pipeline, phase, ok := s.phaseFromRequest(w, r)
+	if !ok {
 		return
 	}
 
-	v, err := phase.Get(r.Context())
+	var (
+		response = s.createPhaseResponse(phase, pipeline.Dependencies())
+		err      error
+	)
+
+	response.Value, err = value(r.Context(), phase)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	response := s.createPhaseResponse(phase, pipeline.Dependencies())
-	response.Value = v
+	response.Verification, err = verification(r.Context(), phase)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Pinned, err = pinned(r.Context(), phase)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response.Proposals, err = proposals(r.Context(), phase)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if gated, ok := phase.(core.GatedPhase); ok {
+		response.Gates, err = gated.EvaluateGates(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -214,15 +433,257 @@ func (s *Server) getPhase(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type planResponse struct {
+	Pending bool       `json:"pending"`
+	Plan    *core.Plan `json:"plan,omitempty"`
+}
+
 func (s *Server) promotePhase(w http.ResponseWriter, r *http.Request) {
-	pipeline, err := s.system.GetPipeline(chi.URLParam(r, "pipeline"))
+	pipeline, phase, ok := s.phaseFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run")); dryRun {
+		plannable, ok := phase.(core.PlannablePhase)
+		if !ok {
+			http.Error(w, core.ErrNotSupported.Error(), http.StatusNotImplemented)
+			return
+		}
+
+		plan, err := plannable.Plan(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(planResponse{Pending: plan != nil, Plan: plan}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if !s.authorize(w, r, auth.ActionPromote, pipeline.Metadata(), phase.Metadata()) {
+		return
+	}
+
+	if err := phase.Promote(r.Context()); err != nil {
+		var gateErr *core.GateError
+		if errors.As(err, &gateErr) {
+			// held promotions may succeed later whereas denied promotions will not
+			status := http.StatusConflict
+			if gateErr.Decision() == core.GateDeny {
+				status = http.StatusUnprocessableEntity
+			}
+
+			w.WriteHeader(status)
+			if err := json.NewEncoder(w).Encode(gatesResponse{
+				Decision: gateErr.Decision(),
+				Results:  gateErr.Results,
+			}); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+type gatesResponse struct {
+	Decision core.GateDecision `json:"decision"`
+	Results  []core.GateResult `json:"results"`
+}
+
+type historyResponse struct {
+	States []core.State `json:"states"`
+}
+
+func (s *Server) phaseHistory(w http.ResponseWriter, r *http.Request) {
+	_, phase, ok := s.phaseFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	historical, ok := phase.(core.HistoricalPhase)
+	if !ok {
+		http.Error(w, core.ErrNotSupported.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	states, err := historical.History(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		status := http.StatusInternalServerError
+		if errors.Is(err, core.ErrNotSupported) {
+			status = http.StatusNotImplemented
+		}
+
+		http.Error(w, err.Error(), status)
 		return
 	}
 
-	phaseName := chi.URLParam(r, "phase")
-	phase, err := pipeline.PhaseByName(phaseName)
+	if states == nil {
+		states = []core.State{}
+	}
+
+	if err := json.NewEncoder(w).Encode(historyResponse{States: states}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+type rollbackRequest struct {
+	Revision string `json:"revision"`
+}
+
+func (s *Server) rollbackPhase(w http.ResponseWriter, r *http.Request) {
+	pipeline, phase, ok := s.phaseFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.authorize(w, r, auth.ActionRollback, pipeline.Metadata(), phase.Metadata()) {
+		return
+	}
+
+	historical, ok := phase.(core.HistoricalPhase)
+	if !ok {
+		http.Error(w, core.ErrNotSupported.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	var req rollbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Revision == "" {
+		http.Error(w, "revision is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := historical.Rollback(r.Context(), req.Revision); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, core.ErrNotSupported) {
+			status = http.StatusNotImplemented
+		}
+
+		http.Error(w, err.Error(), status)
+		return
+	}
+}
+
+type pinRequest struct {
+	Revision string `json:"revision,omitempty"`
+}
+
+func (s *Server) pinPhase(w http.ResponseWriter, r *http.Request) {
+	pipeline, phase, ok := s.phaseFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.authorize(w, r, auth.ActionPin, pipeline.Metadata(), phase.Metadata()) {
+		return
+	}
+
+	pinnable, ok := phase.(core.PinnablePhase)
+	if !ok {
+		http.Error(w, core.ErrNotSupported.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	var req pinRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := pinnable.Pin(r.Context(), req.Revision); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, core.ErrNotSupported) {
+			status = http.StatusNotImplemented
+		}
+
+		http.Error(w, err.Error(), status)
+		return
+	}
+}
+
+func (s *Server) unpinPhase(w http.ResponseWriter, r *http.Request) {
+	pipeline, phase, ok := s.phaseFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.authorize(w, r, auth.ActionPin, pipeline.Metadata(), phase.Metadata()) {
+		return
+	}
+
+	pinnable, ok := phase.(core.PinnablePhase)
+	if !ok {
+		http.Error(w, core.ErrNotSupported.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	if err := pinnable.Unpin(r.Context()); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, core.ErrNotSupported) {
+			status = http.StatusNotImplemented
+		}
+
+		http.Error(w, err.Error(), status)
+		return
+	}
+}
+
+type listApprovalsResponse struct {
+	Approvals []*approvals.Approval `json:"approvals"`
+}
+
+func (s *Server) listApprovals(w http.ResponseWriter, r *http.Request) {
+	store, err := s.system.Approvals()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	list, err := store.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// optionally filter by status (e.g. ?status=pending)
+	if status := r.URL.Query().Get("status"); status != "" {
+		filtered := make([]*approvals.Approval, 0, len(list))
+		for _, approval := range list {
+			if string(approval.Status) == status {
+				filtered = append(filtered, approval)
+			}
+		}
+
+		list = filtered
+	}
+
+	if err := json.NewEncoder(w).Encode(listApprovalsResponse{Approvals: list}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s *Server) getApproval(w http.ResponseWriter, r *http.Request) {
+	store, err := s.system.Approvals()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	approval, err := store.Get(r.Context(), chi.URLParam(r, "approval"))
 	if err != nil {
 		status := http.StatusInternalServerError
 		if errors.Is(err, core.ErrNotFound) {
@@ -233,8 +694,121 @@ func (s *Server) promotePhase(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := phase.Promote(r.Context()); err != nil {
+	if err := json.NewEncoder(w).Encode(approval); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 }
+
+func (s *Server) decideApproval(decide func(context.Context, approvals.Store, string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		store, err := s.system.Approvals()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		id := chi.URLParam(r, "approval")
+		if s.authorizer != nil {
+			approval, err := store.Get(r.Context(), id)
+			if err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, core.ErrNotFound) {
+					status = http.StatusNotFound
+				}
+
+				http.Error(w, err.Error(), status)
+				return
+			}
+
+			// authorize against the approvals phase (and its labels) where it is still registered
+			pipeline, phase := core.Metadata{Name: approval.Pipeline}, core.Metadata{Name: approval.Phase}
+			if p, err := s.system.GetPipeline(approval.Pipeline); err == nil {
+				pipeline = p.Metadata()
+				if ph, err := p.PhaseByName(approval.Phase); err == nil {
+					phase = ph.Metadata()
+				}
+			}
+
+			if !s.authorize(w, r, auth.ActionApprove, pipeline, phase) {
+				return
+			}
+		}
+
+		if err := decide(r.Context(), store, id); err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, core.ErrNotFound):
+				status = http.StatusNotFound
+			case errors.Is(err, approvals.ErrAlreadyDecided):
+				status = http.StatusConflict
+			}
+
+			http.Error(w, err.Error(), status)
+			return
+		}
+	}
+}
+
+// streamEvents streams events published on the systems event bus
+// to the client as server-sent events until the client disconnects.
+func (s *Server) streamEvents(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+		rc  = http.NewResponseController(w)
+	)
+
+	// streams are long-lived and so must not be subject to the servers write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		slog.Error("streaming events", "error", err)
+		return
+	}
+
+	var (
+		// subscribe before acknowledging the stream so that no event
+		// published after the client observes the response is missed
+		events    = s.system.events.Subscribe(ctx)
+		keepalive = time.NewTicker(30 * time.Second)
+	)
+
+	defer keepalive.Stop()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		slog.Error("streaming events", "error", err)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepalive.C:
+			if _, err := io.WriteString(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				slog.Error("encoding event", "error", err)
+				continue
+			}
+
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
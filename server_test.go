@@ -0,0 +1,94 @@
+package glu
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/get-glu/glu/pkg/config"
+	"github.com/get-glu/glu/pkg/core"
+	"github.com/get-glu/glu/pkg/phases"
+)
+
+// proposalSource is a phase source with a single open proposal which counts
+// the number of times its proposals are listed.
+type proposalSource struct {
+	listed atomic.Int32
+}
+
+func (p *proposalSource) Type() string {
+	return "fake"
+}
+
+func (p *proposalSource) View(context.Context, core.Metadata, core.Metadata, *versionResource) error {
+	return nil
+}
+
+func (p *proposalSource) Proposals(context.Context, core.Metadata, core.Metadata) ([]core.Proposal, error) {
+	p.listed.Add(1)
+
+	return []core.Proposal{{Branch: "glu/app/staging/abc", BaseBranch: "main", Digest: "abc"}}, nil
+}
+
+func TestServer_PhaseProposals(t *testing.T) {
+	source := &proposalSource{}
+
+	system := NewSystem(context.Background(), Name("test"))
+	system.conf = newConfigSource(&config.Config{})
+	system.AddPipeline(func(ctx context.Context, config *Config) (Pipeline, error) {
+		pipeline := NewPipeline(Name("app"), func() *versionResource { return &versionResource{} })
+		if _, err := phases.New(Name("staging"), pipeline, source); err != nil {
+			return nil, err
+		}
+
+		return pipeline, nil
+	})
+
+	if system.err != nil {
+		t.Fatal(system.err)
+	}
+
+	srv := httptest.NewServer(newServer(system))
+	t.Cleanup(srv.Close)
+
+	var list listPipelinesResponse
+	get(t, srv, "/api/v1/pipelines", &list)
+
+	if len(list.Pipelines) != 1 || len(list.Pipelines[0].Phases) != 1 {
+		t.Fatalf("unexpected pipelines %+v", list.Pipelines)
+	}
+
+	// listing pipelines must not list the proposals of every phase
+	if listed := source.listed.Load(); listed != 0 {
+		t.Errorf("expected proposals not to be listed, listed %d times", listed)
+	}
+
+	var phase phaseResponse
+	get(t, srv, "/api/v1/pipelines/app/phases/staging", &phase)
+
+	if len(phase.Proposals) != 1 || phase.Proposals[0].Digest != "abc" {
+		t.Errorf("unexpected phase proposals %+v", phase.Proposals)
+	}
+}
+
+func get(t *testing.T, srv *httptest.Server, path string, v any) {
+	t.Helper()
+
+	resp, err := srv.Client().Get(srv.URL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET %s: unexpected status %d", path, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+}
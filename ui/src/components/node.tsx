@@ -1,11 +1,22 @@
+import { useEffect, useState } from 'react';
 import { Handle, NodeProps, Position } from '@xyflow/react';
 import { Package, GitBranch, CircleArrowUp } from 'lucide-react';
 import { Badge } from '@/components/ui/badge';
 import { PhaseNode as PhaseNodeType } from '@/types/flow';
+import { Proposal } from '@/types/pipeline';
 import * as Tooltip from '@radix-ui/react-tooltip';
-import { promotePhase } from '@/services/api';
+import { getPhase, promotePhase } from '@/services/api';
 
 const PhaseNode = ({ data }: NodeProps<PhaseNodeType>) => {
+  // proposals are only included when getting a single phase
+  const [proposals, setProposals] = useState<Proposal[]>([]);
+
+  useEffect(() => {
+    getPhase(data.pipeline, data.name)
+      .then((phase) => setProposals(phase.proposals ?? []))
+      .catch(() => setProposals([]));
+  }, [data.pipeline, data.name]);
+
   const getIcon = () => {
     switch (data.source_type ?? '') {
       case 'oci':
@@ -26,7 +37,7 @@ const PhaseNode = ({ data }: NodeProps<PhaseNodeType>) => {
       <div className="flex items-center gap-2">
         {getIcon()}
         <span className="text-sm font-medium">{data.name}</span>
-        {data.depends_on && data.depends_on !== '' && (
+        {data.depends_on && data.depends_on.length > 0 && (
           <Tooltip.Provider>
             <Tooltip.Root>
               <Tooltip.Trigger asChild>
@@ -62,6 +73,18 @@ const PhaseNode = ({ data }: NodeProps<PhaseNodeType>) => {
               </Badge>
             </div>
           ))}
+        {proposals.map((proposal) => (
+          <div key={proposal.branch} className="mb-2 flex">
+            <a href={proposal.url} target="_blank" rel="noreferrer">
+              <Badge
+                variant="outline"
+                className={`whitespace-nowrap text-xs ${getProposalColor(proposal)}`}
+              >
+                {describeProposal(proposal)}
+              </Badge>
+            </a>
+          </div>
+        ))}
       </div>
 
       <Handle type="target" position={Position.Left} style={{ left: -8 }} />
@@ -69,6 +92,34 @@ const PhaseNode = ({ data }: NodeProps<PhaseNodeType>) => {
   );
 };
 
+function describeProposal(proposal: Proposal): string {
+  const name = proposal.number ? `#${proposal.number}` : proposal.branch;
+  if (proposal.mergeable === false) {
+    return `${name} conflicted`;
+  }
+
+  switch (proposal.checks) {
+    case 'failure':
+      return `${name} checks failing`;
+    case 'pending':
+      return `${name} checks pending`;
+    default:
+      return `${name} open`;
+  }
+}
+
+function getProposalColor(proposal: Proposal): string {
+  if (proposal.mergeable === false || proposal.checks === 'failure') {
+    return 'border-red-300 text-red-800 dark:text-red-200';
+  }
+
+  if (proposal.checks === 'pending') {
+    return 'border-yellow-300 text-yellow-800 dark:text-yellow-200';
+  }
+
+  return 'border-green-300 text-green-800 dark:text-green-200';
+}
+
 function getLabelColor(key: string, value: string): string {
   const hash = `${key}:${value}`.split('').reduce((acc, char) => {
     return char.charCodeAt(0) + ((acc << 5) - acc);
@@ -146,13 +146,13 @@ function getElements(pipeline: PipelineType): FlowPipeline {
     };
     nodes.push(node);
 
-    if (phase.depends_on) {
+    phase.depends_on?.forEach((dependsOn) => {
       edges.push({
-        id: `edge-${phase.depends_on}-${phase.name}`,
-        source: phase.depends_on,
+        id: `edge-${dependsOn}-${phase.name}`,
+        source: dependsOn,
         target: phase.name
       });
-    }
+    });
   });
 
   return { nodes, edges };
@@ -1,5 +1,5 @@
 import axios from 'axios';
-import { Pipeline } from '@/types/pipeline';
+import { Phase, Pipeline } from '@/types/pipeline';
 import { System } from '@/types/system';
 
 const api = axios.create({
@@ -16,6 +16,11 @@ export const listPipelines = async (): Promise<Pipeline[]> => {
   return response.data.pipelines;
 };
 
+export const getPhase = async (pipeline: string, phase: string): Promise<Phase> => {
+  const response = await api.get<Phase>(`/pipelines/${pipeline}/phases/${phase}`);
+  return response.data;
+};
+
 export const promotePhase = async (pipeline: string, phase: string) => {
   const response = await api.post(`/pipelines/${pipeline}/phase/${phase}/promote`);
   if (response.status !== 200) {
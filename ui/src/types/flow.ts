@@ -11,7 +11,7 @@ type PhaseNodeData = {
   pipeline: string;
   name: string;
   labels?: Record<string, string>;
-  depends_on?: string;
+  depends_on?: string[];
   source_type?: string;
 };
 
@@ -6,10 +6,23 @@ export interface Pipeline {
 
 export interface Phase {
   name: string;
-  depends_on?: string;
+  depends_on?: string[];
   source_type?: string;
   labels?: Record<string, string>;
   value?: unknown;
+  proposals?: Proposal[];
+}
+
+export interface Proposal {
+  number?: number;
+  url?: string;
+  branch: string;
+  base_branch: string;
+  base_revision?: string;
+  digest: string;
+  checks?: 'pending' | 'success' | 'failure';
+  approvals?: number;
+  mergeable?: boolean;
 }
 
 export interface PipelineGroup {